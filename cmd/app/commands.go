@@ -0,0 +1,186 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/RomanKovalev007/pull_request_service/include/config"
+	"github.com/RomanKovalev007/pull_request_service/include/models"
+	"github.com/RomanKovalev007/pull_request_service/include/repository"
+	"github.com/RomanKovalev007/pull_request_service/include/service"
+	transport "github.com/RomanKovalev007/pull_request_service/include/transport/models"
+)
+
+// runCommand выполняет служебную подкоманду вместо запуска сервера.
+func runCommand(cfg *config.Config, name string, args []string) error {
+	switch name {
+	case "import":
+		return runImport(cfg, args)
+	case "export":
+		return runExport(cfg, args)
+	case "restore":
+		return runRestore(cfg, args)
+	default:
+		return fmt.Errorf("unknown command %q", name)
+	}
+}
+
+func runImport(cfg *config.Config, args []string) error {
+	fs := flag.NewFlagSet("import", flag.ContinueOnError)
+	file := fs.String("file", "", "roster file, - for stdin")
+	format := fs.String("format", "", "roster format: yaml or csv (by file extension if empty)")
+	dryRun := fs.Bool("dry-run", false, "print the plan without applying it")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *file == "" {
+		return fmt.Errorf("-file is required")
+	}
+
+	if *format == "" {
+		*format = service.RosterYAML
+		if strings.EqualFold(filepath.Ext(*file), ".csv") {
+			*format = service.RosterCSV
+		}
+	}
+
+	var in io.Reader = os.Stdin
+	if *file != "-" {
+		f, err := os.Open(*file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		in = f
+	}
+
+	roster, err := service.ParseRoster(in, *format)
+	if err != nil {
+		return fmt.Errorf("invalid roster: %w", err)
+	}
+
+	repo, err := repository.NewDB(cfg.Config)
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer repo.DB.Close()
+
+	selector, err := service.NewReviewerSelector(cfg.ReviewerConfig)
+	if err != nil {
+		return err
+	}
+
+	resp, err := service.NewAdminService(repo.AdminRepository, selector).
+		ImportRoster(context.Background(), transport.ImportRequest{Roster: roster, DryRun: *dryRun})
+	if err != nil {
+		return err
+	}
+
+	printImportPlan(os.Stdout, resp.Plan)
+	return nil
+}
+
+func printImportPlan(w io.Writer, plan models.ImportPlan) {
+	for _, team := range plan.TeamsCreated {
+		fmt.Fprintf(w, "+ team %s\n", team)
+	}
+	for _, user := range plan.UsersCreated {
+		fmt.Fprintf(w, "+ user %s (%s) team=%s active=%t\n", user.UserID, user.Username, user.TeamName, user.IsActive)
+	}
+	for _, user := range plan.UsersUpdated {
+		fmt.Fprintf(w, "~ user %s (%s) team=%s active=%t\n", user.UserID, user.Username, user.TeamName, user.IsActive)
+	}
+	for _, userID := range plan.UsersDeactivated {
+		fmt.Fprintf(w, "- user %s deactivated\n", userID)
+	}
+
+	if plan.Applied {
+		fmt.Fprintln(w, "roster applied")
+	} else {
+		fmt.Fprintln(w, "dry run, nothing applied")
+	}
+}
+
+func runExport(cfg *config.Config, args []string) error {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	file := fs.String("file", "-", "output file, - for stdout")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	repo, err := repository.NewDB(cfg.Config)
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer repo.DB.Close()
+
+	snap, err := service.NewAdminService(repo.AdminRepository, nil).ExportSnapshot(context.Background())
+	if err != nil {
+		return err
+	}
+
+	var out io.Writer = os.Stdout
+	if *file != "-" {
+		f, err := os.Create(*file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		out = f
+	}
+
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	return enc.Encode(snap)
+}
+
+func runRestore(cfg *config.Config, args []string) error {
+	fs := flag.NewFlagSet("restore", flag.ContinueOnError)
+	file := fs.String("file", "", "snapshot file, - for stdin")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *file == "" {
+		return fmt.Errorf("-file is required")
+	}
+
+	var in io.Reader = os.Stdin
+	if *file != "-" {
+		f, err := os.Open(*file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		in = f
+	}
+
+	var snap models.Snapshot
+	if err := json.NewDecoder(in).Decode(&snap); err != nil {
+		return fmt.Errorf("invalid snapshot: %w", err)
+	}
+
+	// Пустой базе нужна схема: при восстановлении миграции применяются до загрузки данных.
+	if err := repository.RunMigrations(cfg.Migration_Path, cfg.DSN); err != nil {
+		return fmt.Errorf("failed to run migrations: %w", err)
+	}
+
+	repo, err := repository.NewDB(cfg.Config)
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer repo.DB.Close()
+
+	resp, err := service.NewAdminService(repo.AdminRepository, nil).RestoreSnapshot(context.Background(), snap)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stdout, "restored %d teams, %d users, %d pull requests\n", resp.Teams, resp.Users, resp.PullRequests)
+	return nil
+}
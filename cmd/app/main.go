@@ -40,6 +40,14 @@ func main() {
 
 	cfg.DSN = cfg.FormatConnectionString()
 
+	// Run maintenance subcommand, e.g. "import -file roster.yaml"
+	if len(os.Args) > 1 {
+		if err := runCommand(cfg, os.Args[1], os.Args[2:]); err != nil {
+			log.Fatalf("%s failed: %v", os.Args[1], err)
+		}
+		return
+	}
+
 	// Wait for DB start
 	time.Sleep(3 * time.Second)
 
@@ -60,15 +68,29 @@ func main() {
 	checkTables(repo)
 
 	// Initialize server
-	server := v1.NewServer(cfg.Port, repo)
+	server, err := v1.NewServer(cfg.Port, repo, cfg.ReviewerConfig)
+	if err != nil {
+		log.Fatalf("Failed to create server: %v", err)
+	}
+
 	err = server.RegisterHandlers()
 	if err != nil {
 		log.Fatalf("Failed to register handlers: %v", err)
 	}
 
-	// Starting server
+	// Starting background workers
+	workersCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+
 	wg := sync.WaitGroup{}
 	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		server.RunWorkers(workersCtx)
+	}()
+
+	// Starting server
+	wg.Add(1)
 	go func() {
 		defer wg.Done()
 		log.Printf("Server starting on %s", cfg.BaseURL)
@@ -84,6 +106,8 @@ func main() {
 
 	log.Println("Shutdown signal received, starting graceful shutdown...")
 
+	stopWorkers()
+
 	shutdownCtx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
 	defer cancel()
 
@@ -99,7 +123,8 @@ func main() {
 }
 
 func checkTables(db *repository.Repo) {
-	tables := []string{"teams", "users", "pull_requests", "pr_reviewers"}
+	tables := []string{"teams", "users", "pull_requests", "pr_reviewers", "team_settings", "team_fallbacks", "user_availability", "reviews",
+		"assignment_events", "outbox_events", "webhooks", "webhook_deliveries", "reviewer_syncs", "team_codeowners"}
 	for _, table := range tables {
 		var exists bool
 		err := db.DB.QueryRow("SELECT EXISTS (SELECT FROM information_schema.tables WHERE table_name = $1)", table).Scan(&exists)
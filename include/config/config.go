@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/RomanKovalev007/pull_request_service/include/repository"
+	"github.com/RomanKovalev007/pull_request_service/include/service"
 	"github.com/ilyakaznacheev/cleanenv"
 )
 
@@ -14,6 +15,7 @@ type Config struct {
 	Migration_Path string `env:"MIGRATION_PATH" env-default:"file:///migrations"`
 
 	repository.Config
+	service.ReviewerConfig
 }
 
 func ParseConfigFromEnv() (*Config, error) {
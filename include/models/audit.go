@@ -0,0 +1,56 @@
+package models
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	AssignmentAssigned   = "assigned"
+	AssignmentReassigned = "reassigned"
+	AssignmentRemoved    = "removed"
+)
+
+// Причины изменения состава ревьюверов в журнале назначений.
+const (
+	ReasonPRCreated        = "pr_created"
+	ReasonReadyForReview   = "ready_for_review"
+	ReasonPRReopened       = "pr_reopened"
+	ReasonPRClosed         = "pr_closed"
+	ReasonConvertedToDraft = "converted_to_draft"
+	ReasonManualReassign   = "manual_reassign"
+	ReasonUserDeactivated  = "user_deactivated"
+	ReasonTeamChanged      = "team_changed"
+	ReasonTeamDeleted      = "team_deleted"
+	ReasonUnavailable      = "user_unavailable"
+	ReasonRosterImport     = "roster_import"
+)
+
+// SystemActor записывается в журнал, когда изменение сделано не по запросу пользователя.
+const SystemActor = "system"
+
+// AssignmentEvent — запись журнала назначений. Журнал только дополняется.
+type AssignmentEvent struct {
+	ID            int64     `json:"id"`
+	PullRequestID string    `json:"pull_request_id"`
+	Action        string    `json:"action"`
+	Actor         string    `json:"actor"`
+	OldReviewerID string    `json:"old_reviewer_id,omitempty"`
+	NewReviewerID string    `json:"new_reviewer_id,omitempty"`
+	Reason        string    `json:"reason"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+type actorKey struct{}
+
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFromContext возвращает автора изменения или SystemActor, если он не задан.
+func ActorFromContext(ctx context.Context) string {
+	if actor, ok := ctx.Value(actorKey{}).(string); ok && actor != "" {
+		return actor
+	}
+	return SystemActor
+}
@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// Availability — период, когда пользователь не может ревьюить.
+type Availability struct {
+	ID              int64      `json:"id"`
+	UserID          string     `json:"user_id"`
+	StartsAt        time.Time  `json:"starts_at"`
+	EndsAt          time.Time  `json:"ends_at"`
+	Reason          string     `json:"reason,omitempty"`
+	ReassignReviews bool       `json:"reassign_reviews"`
+	ReassignedAt    *time.Time `json:"reassigned_at,omitempty"`
+}
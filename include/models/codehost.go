@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+const (
+	SyncPending = "PENDING"
+	SyncSynced  = "SYNCED"
+	SyncFailed  = "FAILED"
+)
+
+// ReviewerSync — состояние выгрузки назначенных ревьюверов в code host. NextAttemptAt
+// не задан у неудачной выгрузки, исчерпавшей попытки.
+type ReviewerSync struct {
+	Status        string     `json:"status"`
+	Attempts      int        `json:"attempts"`
+	LastError     string     `json:"last_error,omitempty"`
+	NextAttemptAt *time.Time `json:"next_attempt_at,omitempty"`
+	SyncedAt      *time.Time `json:"synced_at,omitempty"`
+}
+
+// DueReviewerSync — выгрузка, время попытки которой наступило. Revision — номер
+// постановки в очередь, результат попытки сохраняется, только пока он не изменился.
+// PushedReviewers — ревьюверы, запрошенные в code host последней успешной выгрузкой.
+type DueReviewerSync struct {
+	PullRequestID   string
+	Attempts        int
+	Revision        int64
+	PushedReviewers []string
+}
@@ -9,6 +9,13 @@ const (
 	PREXISTS       ErrorResponseErrorCode = "PR_EXISTS"
 	PRMERGED       ErrorResponseErrorCode = "PR_MERGED"
 	TEAMEXISTS     ErrorResponseErrorCode = "TEAM_EXISTS"
+	USEREXISTS     ErrorResponseErrorCode = "USER_EXISTS"
+	MERGEBLOCKED   ErrorResponseErrorCode = "MERGE_BLOCKED"
+	INVALIDSTATUS  ErrorResponseErrorCode = "INVALID_TRANSITION"
+	TEAMHASREVIEWS ErrorResponseErrorCode = "TEAM_HAS_OPEN_REVIEWS"
+	TEAMORPHANS    ErrorResponseErrorCode = "TEAM_REVIEWS_ORPHANED"
+	NOTEMPTY       ErrorResponseErrorCode = "DATABASE_NOT_EMPTY"
+	INVALIDSIGN    ErrorResponseErrorCode = "INVALID_SIGNATURE"
 	INVALID_INPUT  ErrorResponseErrorCode = "INVALID_INPUT"
 	INTERNAL_ERROR ErrorResponseErrorCode = "INTERNAL_ERROR"
 	STATUS_OK      ErrorResponseErrorCode = "STATUS_OK"
@@ -2,14 +2,48 @@ package models
 
 import "time"
 
+const (
+	StatusDraft  = "DRAFT"
+	StatusOpen   = "OPEN"
+	StatusMerged = "MERGED"
+	StatusClosed = "CLOSED"
+)
+
 type PullRequest struct {
-	PullRequestID     string     `json:"pull_request_id"`
-	PullRequestName   string     `json:"pull_request_name"`
-	AuthorID          string     `json:"author_id"`
-	Status            string     `json:"status"`
-	AssignedReviewers []string   `json:"assigned_reviewers"`
-	CreatedAt         *time.Time `json:"createdAt,omitempty"`
-	MergedAt          *time.Time `json:"mergedAt,omitempty"`
+	PullRequestID     string            `json:"pull_request_id"`
+	PullRequestName   string            `json:"pull_request_name"`
+	AuthorID          string            `json:"author_id"`
+	Status            string            `json:"status"`
+	Repository        string            `json:"repository,omitempty"`
+	SourceBranch      string            `json:"source_branch,omitempty"`
+	TargetBranch      string            `json:"target_branch,omitempty"`
+	Description       string            `json:"description,omitempty"`
+	URL               string            `json:"url,omitempty"`
+	AssignedReviewers []string          `json:"assigned_reviewers"`
+	FallbackReviewers map[string]string `json:"fallback_reviewers,omitempty"`
+	Reviews           []Review          `json:"reviews,omitempty"`
+	ForceMerged       bool              `json:"force_merged,omitempty"`
+	MergedExternally  bool              `json:"merged_externally,omitempty"`
+	ReviewerSync      *ReviewerSync     `json:"reviewer_sync,omitempty"`
+	ChangedFiles      []string          `json:"changed_files,omitempty"`
+	Labels            []string          `json:"labels,omitempty"`
+	// SelectionReasons — почему выбран каждый назначенный ревьювер, заполняется при назначении.
+	SelectionReasons map[string]string `json:"selection_reasons,omitempty"`
+	CreatedAt        *time.Time        `json:"createdAt,omitempty"`
+	MergedAt         *time.Time        `json:"mergedAt,omitempty"`
+	ClosedAt         *time.Time        `json:"closedAt,omitempty"`
+}
+
+// PullRequestUpdate — изменяемые поля PR, nil означает «не менять».
+type PullRequestUpdate struct {
+	PullRequestName *string
+	Repository      *string
+	SourceBranch    *string
+	TargetBranch    *string
+	Description     *string
+	URL             *string
+	// Labels заменяет метки PR целиком.
+	Labels *[]string
 }
 
 type PullRequestShort struct {
@@ -23,3 +57,37 @@ type PRReview struct {
 	PRID     string
 	AuthorID string
 }
+
+// PullRequestFilter — условия выборки PR. Пустые поля не ограничивают выборку.
+// PR должен иметь все метки из Labels, Search ищет подстроку в названии и описании без учёта регистра.
+type PullRequestFilter struct {
+	Status       string
+	AuthorID     string
+	ReviewerID   string
+	TeamName     string
+	Repository   string
+	SourceBranch string
+	TargetBranch string
+	Labels       []string
+	Search       string
+	CreatedFrom  *time.Time
+	CreatedTo    *time.Time
+	MergedFrom   *time.Time
+	MergedTo     *time.Time
+	Limit        int
+	Offset       int
+}
+
+// PageCursor — позиция последнего PR страницы в порядке (created_at, id) по убыванию.
+type PageCursor struct {
+	CreatedAt     time.Time
+	PullRequestID string
+}
+
+// ReviewFilter — условия выборки PR, назначенных ревьюверу.
+type ReviewFilter struct {
+	Status string
+	Since  *time.Time
+	Limit  int
+	After  *PageCursor
+}
@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+type ReviewerCandidate struct {
+	UserID         string
+	TeamName       string
+	Tags           []string
+	OpenReviews    int
+	LastAssignedAt *time.Time
+}
+
+// Причины выбора ревьювера.
+const (
+	SelectedByLabel     = "label_match"
+	SelectedByCodeOwner = "codeowner"
+	SelectedByStrategy  = "strategy"
+)
+
+// ReviewerPickFunc выбирает до count ревьюверов из кандидатов команды teamName.
+type ReviewerPickFunc func(teamName string, candidates []ReviewerCandidate, count int) []string
+
+// ReviewerPreference — правило, по которому часть ревьюверов выбирается раньше остальных,
+// например владельцы изменённых файлов по CODEOWNERS.
+type ReviewerPreference struct {
+	// Reason записывается в SelectionReasons выбранных по правилу ревьюверов.
+	Reason string
+	Match  func(candidate ReviewerCandidate) bool
+	// Limit — сколько ревьюверов выбрать по правилу, 0 — без ограничения.
+	Limit int
+}
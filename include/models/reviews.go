@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+const (
+	ReviewApproved         = "APPROVED"
+	ReviewChangesRequested = "CHANGES_REQUESTED"
+	ReviewCommented        = "COMMENTED"
+)
+
+type Review struct {
+	ReviewerID  string    `json:"reviewer_id"`
+	State       string    `json:"state"`
+	Comment     string    `json:"comment,omitempty"`
+	SubmittedAt time.Time `json:"submitted_at"`
+}
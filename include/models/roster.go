@@ -0,0 +1,18 @@
+package models
+
+// RosterEntry — строка оргструктуры: пользователь и его команда.
+type RosterEntry struct {
+	TeamName string `json:"team" yaml:"team"`
+	UserID   string `json:"user_id" yaml:"user_id"`
+	Username string `json:"username" yaml:"username"`
+	IsActive bool   `json:"is_active" yaml:"is_active"`
+}
+
+// ImportPlan — изменения, которые импорт оргструктуры вносит в базу.
+type ImportPlan struct {
+	TeamsCreated     []string      `json:"teams_created"`
+	UsersCreated     []RosterEntry `json:"users_created"`
+	UsersUpdated     []RosterEntry `json:"users_updated"`
+	UsersDeactivated []string      `json:"users_deactivated"`
+	Applied          bool          `json:"applied"`
+}
@@ -0,0 +1,62 @@
+package models
+
+import "time"
+
+const SnapshotVersion = 1
+
+// Snapshot — полная выгрузка данных сервиса для переноса между окружениями.
+type Snapshot struct {
+	Version      int                   `json:"version"`
+	ExportedAt   time.Time             `json:"exported_at"`
+	Teams        []SnapshotTeam        `json:"teams"`
+	Users        []SnapshotUser        `json:"users"`
+	PullRequests []SnapshotPullRequest `json:"pull_requests"`
+	Availability []Availability        `json:"availability"`
+	// AssignmentEvents переносится целиком, чтобы история назначений не терялась.
+	AssignmentEvents []AssignmentEvent `json:"assignment_events"`
+}
+
+type SnapshotTeam struct {
+	TeamName  string        `json:"team_name"`
+	CreatedAt *time.Time    `json:"created_at,omitempty"`
+	Settings  *TeamSettings `json:"settings,omitempty"`
+	// CodeOwners — загруженный файл CODEOWNERS команды.
+	CodeOwners string `json:"codeowners,omitempty"`
+}
+
+type SnapshotUser struct {
+	UserID         string     `json:"user_id"`
+	Username       string     `json:"username"`
+	TeamName       string     `json:"team_name,omitempty"`
+	IsActive       bool       `json:"is_active"`
+	ReviewCapacity *int       `json:"review_capacity,omitempty"`
+	Tags           []string   `json:"tags,omitempty"`
+	CreatedAt      *time.Time `json:"created_at,omitempty"`
+	UpdatedAt      *time.Time `json:"updated_at,omitempty"`
+}
+
+type SnapshotPullRequest struct {
+	PullRequestID    string             `json:"pull_request_id"`
+	PullRequestName  string             `json:"pull_request_name"`
+	AuthorID         string             `json:"author_id"`
+	Status           string             `json:"status"`
+	Repository       string             `json:"repository,omitempty"`
+	SourceBranch     string             `json:"source_branch,omitempty"`
+	TargetBranch     string             `json:"target_branch,omitempty"`
+	Description      string             `json:"description,omitempty"`
+	URL              string             `json:"url,omitempty"`
+	ForceMerged      bool               `json:"force_merged,omitempty"`
+	MergedExternally bool               `json:"merged_externally,omitempty"`
+	CreatedAt        *time.Time         `json:"created_at,omitempty"`
+	MergedAt         *time.Time         `json:"merged_at,omitempty"`
+	ClosedAt         *time.Time         `json:"closed_at,omitempty"`
+	ChangedFiles     []string           `json:"changed_files,omitempty"`
+	Labels           []string           `json:"labels,omitempty"`
+	Reviewers        []SnapshotReviewer `json:"reviewers"`
+	Reviews          []Review           `json:"reviews"`
+}
+
+type SnapshotReviewer struct {
+	ReviewerID string     `json:"reviewer_id"`
+	AssignedAt *time.Time `json:"assigned_at,omitempty"`
+}
@@ -32,6 +32,8 @@ type TotalStats struct {
 	TotalActiveReviewers int `json:"total_active_reviewers"`
 	MergedPRs            int `json:"merged_prs"`
 	OpenPRs              int `json:"open_prs"`
+	DraftPRs             int `json:"draft_prs"`
+	ClosedPRs            int `json:"closed_prs"`
 }
 
 type StatsResponse struct {
@@ -1,5 +1,14 @@
 package models
 
+import "time"
+
+// Политики удаления команды для открытых ревью её участников.
+const (
+	OpenReviewsReassign = "reassign"
+	OpenReviewsReject   = "reject"
+	OpenReviewsDrop     = "drop"
+)
+
 type TeamMember struct {
 	UserID   string `json:"user_id"`
 	Username string `json:"username"`
@@ -10,3 +19,27 @@ type Team struct {
 	TeamName string       `json:"team_name"`
 	Members  []TeamMember `json:"members"`
 }
+
+type TeamSettings struct {
+	TeamName          string   `json:"team_name"`
+	ReviewerCount     int      `json:"reviewer_count"`
+	MinReviewers      int      `json:"min_reviewers"`
+	RequiredApprovals int      `json:"required_approvals"`
+	FallbackTeams     []string `json:"fallback_teams"`
+}
+
+// TeamSettingsUpdate — изменяемые настройки команды, nil означает «не менять».
+type TeamSettingsUpdate struct {
+	ReviewerCount     *int
+	MinReviewers      *int
+	RequiredApprovals *int
+	// FallbackTeams заменяет список резервных команд целиком.
+	FallbackTeams *[]string
+}
+
+// TeamCodeOwners — загруженный файл CODEOWNERS команды.
+type TeamCodeOwners struct {
+	TeamName  string     `json:"team_name"`
+	Content   string     `json:"content"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
@@ -1,8 +1,45 @@
 package models
 
 type User struct {
-	UserID   string `json:"user_id"`
-	Username string `json:"username"`
-	TeamName string `json:"team_name"`
-	IsActive bool   `json:"is_active"`
+	UserID         string `json:"user_id"`
+	Username       string `json:"username"`
+	TeamName       string `json:"team_name"`
+	IsActive       bool   `json:"is_active"`
+	ReviewCapacity *int   `json:"review_capacity,omitempty"`
+	// Tags — области экспертизы пользователя, например db, frontend, security.
+	Tags []string `json:"tags"`
+}
+
+// UserUpdate — изменяемые поля пользователя, nil означает «не менять».
+type UserUpdate struct {
+	Username *string
+	TeamName *string
+	IsActive *bool
+}
+
+type UserFilter struct {
+	TeamName string
+	IsActive *bool
+}
+
+// Режимы передачи открытых ревью при переводе пользователя в другую команду.
+const (
+	HandoffKeep     = "keep"
+	HandoffReassign = "reassign"
+	HandoffDrop     = "drop"
+)
+
+// Что произошло с открытым ревью пользователя.
+const (
+	ReviewKept       = "kept"
+	ReviewReassigned = "reassigned"
+	ReviewDropped    = "dropped"
+)
+
+type ReviewHandoff struct {
+	PullRequestID string `json:"pull_request_id"`
+	// ReviewerID заполняется, когда в отчёте ревью нескольких пользователей
+	ReviewerID string `json:"reviewer_id,omitempty"`
+	Action     string `json:"action"`
+	ReplacedBy string `json:"replaced_by,omitempty"`
 }
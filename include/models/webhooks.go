@@ -0,0 +1,61 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Типы доменных событий, которые рассылаются подписчикам.
+const (
+	EventPRCreated          = "pr.created"
+	EventPRMerged           = "pr.merged"
+	EventReviewerAssigned   = "reviewer.assigned"
+	EventReviewerReassigned = "reviewer.reassigned"
+	EventUserDeactivated    = "user.deactivated"
+)
+
+var EventTypes = []string{
+	EventPRCreated,
+	EventPRMerged,
+	EventReviewerAssigned,
+	EventReviewerReassigned,
+	EventUserDeactivated,
+}
+
+const (
+	DeliveryPending   = "PENDING"
+	DeliveryDelivered = "DELIVERED"
+	DeliveryDead      = "DEAD"
+)
+
+// Webhook — подписка на события. Пустой Events означает подписку на все события.
+type Webhook struct {
+	ID        int64     `json:"id"`
+	URL       string    `json:"url"`
+	Secret    string    `json:"secret,omitempty"`
+	Events    []string  `json:"events"`
+	IsActive  bool      `json:"is_active"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// OutboxEvent — событие, записанное в outbox в транзакции изменения.
+type OutboxEvent struct {
+	ID        int64           `json:"id"`
+	Type      string          `json:"type"`
+	Payload   json.RawMessage `json:"data"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+// WebhookDelivery — доставка одного события одному подписчику.
+type WebhookDelivery struct {
+	ID             int64       `json:"id"`
+	WebhookID      int64       `json:"webhook_id"`
+	URL            string      `json:"url"`
+	Secret         string      `json:"-"`
+	Event          OutboxEvent `json:"event"`
+	Status         string      `json:"status"`
+	Attempts       int         `json:"attempts"`
+	NextAttemptAt  time.Time   `json:"next_attempt_at"`
+	LastError      string      `json:"last_error,omitempty"`
+	LastStatusCode *int        `json:"last_status_code,omitempty"`
+}
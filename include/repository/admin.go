@@ -0,0 +1,181 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+
+	"github.com/RomanKovalev007/pull_request_service/include/models"
+)
+
+type AdminRepository struct {
+	db *sql.DB
+}
+
+func NewAdminRepository(db *sql.DB) *AdminRepository {
+	return &AdminRepository{db: db}
+}
+
+// ImportRoster сверяет оргструктуру с базой: создаёт недостающие команды и пользователей,
+// обновляет изменившихся и деактивирует пользователей, которых нет в roster.
+// Команды, отсутствующие в roster, не удаляются. При dryRun изменения не применяются.
+func (r *AdminRepository) ImportRoster(ctx context.Context, roster []models.RosterEntry, dryRun bool, pick models.ReviewerPickFunc) (*models.ImportPlan, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	teams, err := lockTeams(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	users, err := lockRosterUsers(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := models.ImportPlan{
+		TeamsCreated:     []string{},
+		UsersCreated:     []models.RosterEntry{},
+		UsersUpdated:     []models.RosterEntry{},
+		UsersDeactivated: []string{},
+	}
+
+	inRoster := make(map[string]bool, len(roster))
+	for _, entry := range roster {
+		inRoster[entry.UserID] = true
+
+		if !teams[entry.TeamName] {
+			teams[entry.TeamName] = true
+			plan.TeamsCreated = append(plan.TeamsCreated, entry.TeamName)
+		}
+
+		current, ok := users[entry.UserID]
+		switch {
+		case !ok:
+			plan.UsersCreated = append(plan.UsersCreated, entry)
+		case current != entry:
+			plan.UsersUpdated = append(plan.UsersUpdated, entry)
+		}
+	}
+
+	for userID, current := range users {
+		if !inRoster[userID] && current.IsActive {
+			plan.UsersDeactivated = append(plan.UsersDeactivated, userID)
+		}
+	}
+	sort.Strings(plan.UsersDeactivated)
+
+	if dryRun {
+		return &plan, nil
+	}
+
+	for _, teamName := range plan.TeamsCreated {
+		if _, err := tx.ExecContext(ctx, "INSERT INTO teams (team_name) VALUES ($1)", teamName); err != nil {
+			return nil, fmt.Errorf("failed to create team %s: %w", teamName, err)
+		}
+	}
+
+	for _, entry := range plan.UsersCreated {
+		_, err := tx.ExecContext(ctx, `
+            INSERT INTO users (id, username, team_name, is_active)
+            VALUES ($1, $2, $3, $4)`,
+			entry.UserID, entry.Username, entry.TeamName, entry.IsActive)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create user %s: %w", entry.UserID, err)
+		}
+	}
+
+	// ревью переназначаются после всех изменений, чтобы замены выбирались из итогового состава
+	var handoffs []models.RosterEntry
+	for _, entry := range plan.UsersUpdated {
+		_, err := tx.ExecContext(ctx, `
+            UPDATE users
+            SET username = $2, team_name = $3, is_active = $4, updated_at = CURRENT_TIMESTAMP
+            WHERE id = $1`,
+			entry.UserID, entry.Username, entry.TeamName, entry.IsActive)
+		if err != nil {
+			return nil, fmt.Errorf("failed to update user %s: %w", entry.UserID, err)
+		}
+
+		current := users[entry.UserID]
+		if current.TeamName != entry.TeamName || (current.IsActive && !entry.IsActive) {
+			handoffs = append(handoffs, current)
+		}
+		if current.IsActive && !entry.IsActive {
+			if err := emitUserDeactivated(ctx, tx, entry.UserID); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for _, userID := range plan.UsersDeactivated {
+		_, err := tx.ExecContext(ctx, `
+            UPDATE users
+            SET is_active = false, updated_at = CURRENT_TIMESTAMP
+            WHERE id = $1`, userID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to deactivate user %s: %w", userID, err)
+		}
+		if err := emitUserDeactivated(ctx, tx, userID); err != nil {
+			return nil, err
+		}
+		handoffs = append(handoffs, users[userID])
+	}
+
+	for _, user := range handoffs {
+		if _, err := reassignUserReviews(ctx, tx, user.UserID, user.TeamName, models.ReasonRosterImport, pick); err != nil {
+			return nil, fmt.Errorf("failed to reassign reviews of %s: %w", user.UserID, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to tx commit: %w", err)
+	}
+
+	plan.Applied = true
+	return &plan, nil
+}
+
+func lockTeams(ctx context.Context, tx *sql.Tx) (map[string]bool, error) {
+	rows, err := tx.QueryContext(ctx, "SELECT team_name FROM teams FOR UPDATE")
+	if err != nil {
+		return nil, fmt.Errorf("failed to select teams: %w", err)
+	}
+	defer rows.Close()
+
+	teams := make(map[string]bool)
+	for rows.Next() {
+		var teamName string
+		if err := rows.Scan(&teamName); err != nil {
+			return nil, fmt.Errorf("failed to scan team: %w", err)
+		}
+		teams[teamName] = true
+	}
+
+	return teams, rows.Err()
+}
+
+func lockRosterUsers(ctx context.Context, tx *sql.Tx) (map[string]models.RosterEntry, error) {
+	rows, err := tx.QueryContext(ctx, `
+        SELECT id, username, COALESCE(team_name, ''), is_active
+        FROM users
+        FOR UPDATE`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select users: %w", err)
+	}
+	defer rows.Close()
+
+	users := make(map[string]models.RosterEntry)
+	for rows.Next() {
+		var user models.RosterEntry
+		if err := rows.Scan(&user.UserID, &user.Username, &user.TeamName, &user.IsActive); err != nil {
+			return nil, fmt.Errorf("failed to scan user: %w", err)
+		}
+		users[user.UserID] = user
+	}
+
+	return users, rows.Err()
+}
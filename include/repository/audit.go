@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/RomanKovalev007/pull_request_service/include/models"
+)
+
+// recordAssignment дописывает событие в журнал назначений в транзакции изменения.
+// Автор берётся из контекста запроса, назначение и замена ревьювера также попадают в outbox.
+func recordAssignment(ctx context.Context, tx *sql.Tx, prID, action, oldReviewerID, newReviewerID, reason string) error {
+	e := models.AssignmentEvent{
+		PullRequestID: prID,
+		Action:        action,
+		Actor:         models.ActorFromContext(ctx),
+		OldReviewerID: oldReviewerID,
+		NewReviewerID: newReviewerID,
+		Reason:        reason,
+	}
+
+	err := tx.QueryRowContext(ctx, `
+        INSERT INTO assignment_events (pull_request_id, action, actor, old_reviewer_id, new_reviewer_id, reason)
+        VALUES ($1, $2, $3, NULLIF($4, ''), NULLIF($5, ''), $6)
+        RETURNING id, created_at`,
+		e.PullRequestID, e.Action, e.Actor, e.OldReviewerID, e.NewReviewerID, e.Reason).Scan(&e.ID, &e.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to record assignment event: %w", err)
+	}
+
+	// новый состав ревьюверов выгружается в code host фоновым воркером
+	if err := requeueReviewerSync(ctx, tx, prID); err != nil {
+		return err
+	}
+
+	switch action {
+	case models.AssignmentAssigned:
+		return emitEvent(ctx, tx, models.EventReviewerAssigned, e)
+	case models.AssignmentReassigned:
+		return emitEvent(ctx, tx, models.EventReviewerReassigned, e)
+	}
+	return nil
+}
+
+// GetAssignmentHistory возвращает журнал назначений PR в порядке записи.
+func (r *PrRepository) GetAssignmentHistory(ctx context.Context, prID string) ([]models.AssignmentEvent, error) {
+	var exists bool
+	err := r.db.QueryRowContext(ctx, "SELECT EXISTS(SELECT 1 FROM pull_requests WHERE id = $1)", prID).Scan(&exists)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check pr exists: %w", err)
+	}
+	if !exists {
+		return nil, ErrNotFound
+	}
+
+	return findAssignmentEvents(ctx, r.db, "WHERE pull_request_id = $1", prID)
+}
+
+func findAssignmentEvents(ctx context.Context, q queryer, where string, args ...any) ([]models.AssignmentEvent, error) {
+	rows, err := q.QueryContext(ctx, `
+        SELECT id, pull_request_id, action, actor, COALESCE(old_reviewer_id, ''), COALESCE(new_reviewer_id, ''), reason, created_at
+        FROM assignment_events
+        `+where+`
+        ORDER BY id`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select assignment events: %w", err)
+	}
+	defer rows.Close()
+
+	events := []models.AssignmentEvent{}
+	for rows.Next() {
+		var e models.AssignmentEvent
+		if err := rows.Scan(&e.ID, &e.PullRequestID, &e.Action, &e.Actor, &e.OldReviewerID, &e.NewReviewerID, &e.Reason, &e.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan assignment event: %w", err)
+		}
+		events = append(events, e)
+	}
+
+	return events, rows.Err()
+}
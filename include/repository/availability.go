@@ -0,0 +1,179 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/RomanKovalev007/pull_request_service/include/models"
+)
+
+func (r *UserRepository) CreateAvailability(ctx context.Context, a models.Availability) (*models.Availability, error) {
+	var exists bool
+	err := r.db.QueryRowContext(ctx, "SELECT EXISTS(SELECT 1 FROM users WHERE id = $1)", a.UserID).Scan(&exists)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check user exists: %w", err)
+	}
+	if !exists {
+		return nil, ErrNotFound
+	}
+
+	result, err := scanAvailability(r.db.QueryRowContext(ctx, `
+        INSERT INTO user_availability (user_id, starts_at, ends_at, reason, reassign_reviews)
+        VALUES ($1, $2, $3, $4, $5)
+        RETURNING id, user_id, starts_at, ends_at, reason, reassign_reviews, reassigned_at`,
+		a.UserID, a.StartsAt, a.EndsAt, a.Reason, a.ReassignReviews))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create availability: %w", err)
+	}
+
+	return result, nil
+}
+
+func (r *UserRepository) GetUserAvailability(ctx context.Context, userID string) ([]models.Availability, error) {
+	var exists bool
+	err := r.db.QueryRowContext(ctx, "SELECT EXISTS(SELECT 1 FROM users WHERE id = $1)", userID).Scan(&exists)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check user exists: %w", err)
+	}
+	if !exists {
+		return nil, ErrNotFound
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+        SELECT id, user_id, starts_at, ends_at, reason, reassign_reviews, reassigned_at
+        FROM user_availability
+        WHERE user_id = $1
+        ORDER BY starts_at`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select availability: %w", err)
+	}
+	defer rows.Close()
+
+	periods := []models.Availability{}
+	for rows.Next() {
+		a, err := scanAvailability(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan availability: %w", err)
+		}
+		periods = append(periods, *a)
+	}
+
+	return periods, rows.Err()
+}
+
+// UpdateAvailability сбрасывает отметку о переназначении, чтобы изменённый период обработался заново.
+func (r *UserRepository) UpdateAvailability(ctx context.Context, a models.Availability) (*models.Availability, error) {
+	result, err := scanAvailability(r.db.QueryRowContext(ctx, `
+        UPDATE user_availability
+        SET starts_at = $1, ends_at = $2, reason = $3, reassign_reviews = $4, reassigned_at = NULL
+        WHERE id = $5
+        RETURNING id, user_id, starts_at, ends_at, reason, reassign_reviews, reassigned_at`,
+		a.StartsAt, a.EndsAt, a.Reason, a.ReassignReviews, a.ID))
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to update availability: %w", err)
+	}
+
+	return result, nil
+}
+
+func (r *UserRepository) DeleteAvailability(ctx context.Context, id int64) error {
+	res, err := r.db.ExecContext(ctx, "DELETE FROM user_availability WHERE id = $1", id)
+	if err != nil {
+		return fmt.Errorf("failed to delete availability: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to delete availability: %w", err)
+	}
+	if affected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// ReassignUnavailableReviews переназначает открытые ревью пользователей,
+// у которых начался период отсутствия с флагом reassign_reviews.
+func (r *UserRepository) ReassignUnavailableReviews(ctx context.Context, pick models.ReviewerPickFunc) (int, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, `
+        SELECT a.id, a.user_id, COALESCE(u.team_name, '')
+        FROM user_availability a
+        JOIN users u ON u.id = a.user_id
+        WHERE a.reassign_reviews = true
+        AND a.reassigned_at IS NULL
+        AND a.starts_at <= CURRENT_TIMESTAMP
+        AND a.ends_at > CURRENT_TIMESTAMP
+        ORDER BY a.starts_at
+        FOR UPDATE OF a SKIP LOCKED`)
+	if err != nil {
+		return 0, fmt.Errorf("failed to select started availability: %w", err)
+	}
+
+	type window struct {
+		id       int64
+		userID   string
+		teamName string
+	}
+
+	var windows []window
+	for rows.Next() {
+		var w window
+		if err := rows.Scan(&w.id, &w.userID, &w.teamName); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("failed to scan availability: %w", err)
+		}
+		windows = append(windows, w)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("rows error: %w", err)
+	}
+
+	for _, w := range windows {
+		if _, err := reassignUserReviews(ctx, tx, w.userID, w.teamName, models.ReasonUnavailable, pick); err != nil {
+			return 0, fmt.Errorf("failed to reassign reviews of %s: %w", w.userID, err)
+		}
+
+		_, err = tx.ExecContext(ctx, `
+            UPDATE user_availability
+            SET reassigned_at = CURRENT_TIMESTAMP
+            WHERE id = $1`, w.id)
+		if err != nil {
+			return 0, fmt.Errorf("failed to mark availability processed: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to tx commit: %w", err)
+	}
+
+	return len(windows), nil
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanAvailability(row rowScanner) (*models.Availability, error) {
+	var a models.Availability
+	var reassignedAt sql.NullTime
+
+	if err := row.Scan(&a.ID, &a.UserID, &a.StartsAt, &a.EndsAt, &a.Reason, &a.ReassignReviews, &reassignedAt); err != nil {
+		return nil, err
+	}
+	if reassignedAt.Valid {
+		a.ReassignedAt = &reassignedAt.Time
+	}
+
+	return &a, nil
+}
@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/RomanKovalev007/pull_request_service/include/models"
+	"github.com/lib/pq"
+)
+
+// QueueReviewerSync ставит выгрузку ревьюверов PR в очередь с нуля попыток. Попытку
+// выполняет фоновый воркер, запрос клиента не ждёт code host.
+func (r *PrRepository) QueueReviewerSync(ctx context.Context, prID string) (*models.ReviewerSync, error) {
+	row := r.db.QueryRowContext(ctx, `
+        INSERT INTO reviewer_syncs (pull_request_id, status, attempts, last_error, next_attempt_at, revision)
+        VALUES ($1, 'PENDING', 0, '', CURRENT_TIMESTAMP, 1)
+        ON CONFLICT (pull_request_id) DO UPDATE
+        SET status = 'PENDING', attempts = 0, last_error = '', next_attempt_at = CURRENT_TIMESTAMP,
+            revision = reviewer_syncs.revision + 1
+        RETURNING status, attempts, last_error, next_attempt_at, synced_at`, prID)
+
+	sync, err := scanReviewerSync(row)
+	if err != nil {
+		return nil, fmt.Errorf("failed to queue reviewer sync: %w", err)
+	}
+	return sync, nil
+}
+
+// requeueReviewerSync заново ставит в очередь выгрузку PR, ревьюверы которого изменились.
+// PR, которые ещё не выгружались в code host, пропускаются.
+func requeueReviewerSync(ctx context.Context, tx *sql.Tx, prID string) error {
+	_, err := tx.ExecContext(ctx, `
+        UPDATE reviewer_syncs
+        SET status = 'PENDING', attempts = 0, last_error = '', next_attempt_at = CURRENT_TIMESTAMP,
+            revision = revision + 1
+        WHERE pull_request_id = $1`, prID)
+	if err != nil {
+		return fmt.Errorf("failed to requeue reviewer sync: %w", err)
+	}
+	return nil
+}
+
+// ClaimDueReviewerSyncs выбирает невыгруженные PR, время попытки которых наступило,
+// и откладывает их на lease, чтобы другой экземпляр сервиса не выгрузил их одновременно.
+func (r *PrRepository) ClaimDueReviewerSyncs(ctx context.Context, limit int, lease time.Duration) ([]models.DueReviewerSync, error) {
+	rows, err := r.db.QueryContext(ctx, `
+        WITH due AS (
+            SELECT pull_request_id FROM reviewer_syncs
+            WHERE status <> 'SYNCED' AND next_attempt_at <= CURRENT_TIMESTAMP
+            ORDER BY next_attempt_at, pull_request_id
+            LIMIT $1
+            FOR UPDATE SKIP LOCKED
+        )
+        UPDATE reviewer_syncs s
+        SET next_attempt_at = CURRENT_TIMESTAMP + $2::bigint * INTERVAL '1 millisecond'
+        FROM due
+        WHERE s.pull_request_id = due.pull_request_id
+        RETURNING s.pull_request_id, s.attempts, s.revision, s.pushed_reviewers`,
+		limit, lease.Milliseconds())
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim reviewer syncs: %w", err)
+	}
+	defer rows.Close()
+
+	var due []models.DueReviewerSync
+	for rows.Next() {
+		var d models.DueReviewerSync
+		if err := rows.Scan(&d.PullRequestID, &d.Attempts, &d.Revision, pq.Array(&d.PushedReviewers)); err != nil {
+			return nil, fmt.Errorf("failed to scan reviewer sync: %w", err)
+		}
+		due = append(due, d)
+	}
+
+	return due, rows.Err()
+}
+
+// MarkReviewerSynced фиксирует успешную попытку и запоминает выгруженных ревьюверов pushed.
+// Если после выбора выгрузка была поставлена в очередь заново, результат устарел:
+// ничего не меняется и возвращается nil.
+func (r *PrRepository) MarkReviewerSynced(ctx context.Context, prID string, revision int64, pushed []string) (*models.ReviewerSync, error) {
+	row := r.db.QueryRowContext(ctx, `
+        UPDATE reviewer_syncs
+        SET status = 'SYNCED', attempts = attempts + 1, last_error = '',
+            next_attempt_at = NULL, synced_at = CURRENT_TIMESTAMP, pushed_reviewers = COALESCE($3::text[], '{}')
+        WHERE pull_request_id = $1 AND revision = $2
+        RETURNING status, attempts, last_error, next_attempt_at, synced_at`, prID, revision, pq.Array(pushed))
+
+	sync, err := scanReviewerSync(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to mark reviewer sync synced: %w", err)
+	}
+	return sync, nil
+}
+
+// MarkReviewerSyncFailed фиксирует неудачную попытку. Если nextAttemptAt не задан,
+// выгрузка больше не повторяется. Устаревший результат не сохраняется, как в MarkReviewerSynced.
+func (r *PrRepository) MarkReviewerSyncFailed(ctx context.Context, prID string, revision int64, lastError string, nextAttemptAt *time.Time) (*models.ReviewerSync, error) {
+	row := r.db.QueryRowContext(ctx, `
+        UPDATE reviewer_syncs
+        SET status = 'FAILED', attempts = attempts + 1, last_error = $3, next_attempt_at = $4
+        WHERE pull_request_id = $1 AND revision = $2
+        RETURNING status, attempts, last_error, next_attempt_at, synced_at`, prID, revision, lastError, nextAttemptAt)
+
+	sync, err := scanReviewerSync(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to mark reviewer sync failed: %w", err)
+	}
+	return sync, nil
+}
+
+// scanReviewerSync читает состояние выгрузки; lead — колонки, выбранные перед ним.
+func scanReviewerSync(row rowScanner, lead ...any) (*models.ReviewerSync, error) {
+	var sync models.ReviewerSync
+	var nextAttemptAt, syncedAt sql.NullTime
+
+	dest := append(lead, &sync.Status, &sync.Attempts, &sync.LastError, &nextAttemptAt, &syncedAt)
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+	if nextAttemptAt.Valid {
+		sync.NextAttemptAt = &nextAttemptAt.Time
+	}
+	if syncedAt.Valid {
+		sync.SyncedAt = &syncedAt.Time
+	}
+
+	return &sync, nil
+}
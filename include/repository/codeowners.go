@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/RomanKovalev007/pull_request_service/include/models"
+)
+
+const teamCodeOwnersQuery = `
+        SELECT t.team_name, COALESCE(c.content, ''), c.updated_at
+        FROM teams t
+        LEFT JOIN team_codeowners c ON c.team_name = t.team_name
+        WHERE t.team_name = $1`
+
+// GetTeamCodeOwners возвращает загруженный CODEOWNERS команды. Если файл не загружен,
+// Content пуст.
+func (r *TeamRepository) GetTeamCodeOwners(ctx context.Context, teamName string) (*models.TeamCodeOwners, error) {
+	return findTeamCodeOwners(ctx, r.db, teamCodeOwnersQuery, teamName)
+}
+
+// SetTeamCodeOwners сохраняет CODEOWNERS команды. Пустой Content удаляет файл.
+func (r *TeamRepository) SetTeamCodeOwners(ctx context.Context, owners models.TeamCodeOwners) (*models.TeamCodeOwners, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	var exists bool
+	err = tx.QueryRowContext(ctx, "SELECT EXISTS(SELECT 1 FROM teams WHERE team_name = $1)", owners.TeamName).Scan(&exists)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check team exists: %w", err)
+	}
+	if !exists {
+		return nil, ErrNotFound
+	}
+
+	if owners.Content == "" {
+		_, err = tx.ExecContext(ctx, "DELETE FROM team_codeowners WHERE team_name = $1", owners.TeamName)
+	} else {
+		_, err = tx.ExecContext(ctx, `
+            INSERT INTO team_codeowners (team_name, content)
+            VALUES ($1, $2)
+            ON CONFLICT (team_name)
+            DO UPDATE SET content = $2, updated_at = CURRENT_TIMESTAMP`,
+			owners.TeamName, owners.Content)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to save team codeowners: %w", err)
+	}
+
+	result, err := findTeamCodeOwners(ctx, tx, teamCodeOwnersQuery, owners.TeamName)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to tx commit: %w", err)
+	}
+
+	return result, nil
+}
+
+// GetAuthorCodeOwners возвращает команду автора и её загруженный CODEOWNERS.
+func (r *PrRepository) GetAuthorCodeOwners(ctx context.Context, authorID string) (*models.TeamCodeOwners, error) {
+	return findTeamCodeOwners(ctx, r.db, `
+        SELECT u.team_name, COALESCE(c.content, ''), c.updated_at
+        FROM users u
+        LEFT JOIN team_codeowners c ON c.team_name = u.team_name
+        WHERE u.id = $1 AND u.team_name IS NOT NULL`, authorID)
+}
+
+func findTeamCodeOwners(ctx context.Context, q queryer, query, arg string) (*models.TeamCodeOwners, error) {
+	var owners models.TeamCodeOwners
+	var updatedAt sql.NullTime
+
+	err := q.QueryRowContext(ctx, query, arg).Scan(&owners.TeamName, &owners.Content, &updatedAt)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to select team codeowners: %w", err)
+	}
+	owners.UpdatedAt = nullTime(updatedAt)
+
+	return &owners, nil
+}
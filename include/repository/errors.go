@@ -3,10 +3,17 @@ package repository
 import "errors"
 
 var (
-	ErrNotFound    = errors.New("NOT_FOUND")
-	ErrPRExists    = errors.New("PR_EXISTS")
-	ErrTeamExists  = errors.New("TEAM_EXISTS")
-	ErrPRMerged    = errors.New("PR_MERGED")
-	ErrNotAssigned = errors.New("NOT_ASSIGNED")
-	ErrNoCandidate = errors.New("NO_CANDIDATE")
+	ErrNotFound     = errors.New("NOT_FOUND")
+	ErrPRExists     = errors.New("PR_EXISTS")
+	ErrTeamExists   = errors.New("TEAM_EXISTS")
+	ErrUserExists   = errors.New("USER_EXISTS")
+	ErrPRMerged     = errors.New("PR_MERGED")
+	ErrNotAssigned  = errors.New("NOT_ASSIGNED")
+	ErrNoCandidate  = errors.New("NO_CANDIDATE")
+	ErrMergeBlocked = errors.New("MERGE_BLOCKED")
+
+	ErrInvalidTransition   = errors.New("INVALID_TRANSITION")
+	ErrTeamHasOpenReviews  = errors.New("TEAM_HAS_OPEN_REVIEWS")
+	ErrTeamReviewsOrphaned = errors.New("TEAM_REVIEWS_ORPHANED")
+	ErrNotEmpty            = errors.New("DATABASE_NOT_EMPTY")
 )
@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/RomanKovalev007/pull_request_service/include/models"
+)
+
+// emitEvent записывает доменное событие в outbox в транзакции изменения.
+// Подписчикам его рассылает диспетчер вебхуков после коммита.
+func emitEvent(ctx context.Context, tx *sql.Tx, eventType string, payload any) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s event: %w", eventType, err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+        INSERT INTO outbox_events (event_type, payload)
+        VALUES ($1, $2)`, eventType, data)
+	if err != nil {
+		return fmt.Errorf("failed to emit %s event: %w", eventType, err)
+	}
+	return nil
+}
+
+func emitUserDeactivated(ctx context.Context, tx *sql.Tx, userID string) error {
+	user, err := getUser(ctx, tx, userID)
+	if err != nil {
+		return err
+	}
+	return emitEvent(ctx, tx, models.EventUserDeactivated, user)
+}
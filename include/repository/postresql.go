@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 
@@ -21,14 +22,22 @@ func (c *Config) FormatConnectionString() string {
 		c.DBUser, c.DBPassword, c.DBHost, c.DBPort, c.DBName)
 }
 
+// queryer — общее подмножество *sql.DB и *sql.Tx для вспомогательных выборок.
+type queryer interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
 type Repo struct {
 	DB  *sql.DB
 	DSN string
 
-	UserRepository  *UserRepository
-	TeamRepository  *TeamRepository
-	PrRepository    *PrRepository
-	StatsRepository *StatsRepository
+	UserRepository    *UserRepository
+	TeamRepository    *TeamRepository
+	PrRepository      *PrRepository
+	StatsRepository   *StatsRepository
+	AdminRepository   *AdminRepository
+	WebhookRepository *WebhookRepository
 }
 
 func NewDB(cfg Config) (*Repo, error) {
@@ -50,10 +59,12 @@ func NewDB(cfg Config) (*Repo, error) {
 	db.SetConnMaxLifetime(5 * 60)
 
 	return &Repo{
-		DB:              db,
-		DSN:             cfg.DSN,
-		UserRepository:  NewUserRepository(db),
-		TeamRepository:  NewTeamRepository(db),
-		PrRepository:    NewPrRepository(db),
-		StatsRepository: NewStatsRepository(db)}, nil
+		DB:                db,
+		DSN:               cfg.DSN,
+		UserRepository:    NewUserRepository(db),
+		TeamRepository:    NewTeamRepository(db),
+		PrRepository:      NewPrRepository(db),
+		StatsRepository:   NewStatsRepository(db),
+		AdminRepository:   NewAdminRepository(db),
+		WebhookRepository: NewWebhookRepository(db)}, nil
 }
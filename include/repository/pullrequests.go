@@ -4,8 +4,15 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strings"
 
 	"github.com/RomanKovalev007/pull_request_service/include/models"
+	"github.com/lib/pq"
+)
+
+const (
+	defaultReviewerCount = 2
+	defaultMinReviewers  = 1
 )
 
 type PrRepository struct {
@@ -16,7 +23,7 @@ func NewPrRepository(db *sql.DB) *PrRepository {
 	return &PrRepository{db: db}
 }
 
-func (r *PrRepository) CreatePullRequest(ctx context.Context, req models.PullRequestShort) (*models.PullRequest, error) {
+func (r *PrRepository) CreatePullRequest(ctx context.Context, req models.PullRequest, pick models.ReviewerPickFunc, prefs []models.ReviewerPreference) (*models.PullRequest, error) {
 	tx, err := r.db.Begin()
 	if err != nil {
 		return nil, fmt.Errorf("failed to begin tx: %w", err)
@@ -38,7 +45,7 @@ func (r *PrRepository) CreatePullRequest(ctx context.Context, req models.PullReq
 	var authorTeam string
 	var authorActive bool
 	err = tx.QueryRowContext(ctx, `
-		SELECT team_name, is_active FROM users 
+		SELECT COALESCE(team_name, ''), is_active FROM users 
 		WHERE id = $1`,
 		req.AuthorID).Scan(&authorTeam, &authorActive)
 	if err == sql.ErrNoRows {
@@ -47,53 +54,44 @@ func (r *PrRepository) CreatePullRequest(ctx context.Context, req models.PullReq
 		return nil, fmt.Errorf("failed to select author of pr: %w", err)
 	}
 
-	if !authorActive {
+	// автор без команды не может получить ревьюверов
+	if !authorActive || authorTeam == "" {
 		return nil, ErrNotFound
 	}
 
-	rows, err := tx.Query(`
-        SELECT id FROM users 
-        WHERE team_name = $1 AND is_active = true AND id != $2 
-        ORDER BY RANDOM() 
-        LIMIT 2`,
-		authorTeam, req.AuthorID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to select reviewers: %w", err)
-	}
-	defer rows.Close()
-	var reviewers []string
-	for rows.Next() {
-		var reviewerID string
-		if err := rows.Scan(&reviewerID); err != nil {
-			return nil, fmt.Errorf("failed to scan reviewers: %w", err)
-		}
-		reviewers = append(reviewers, reviewerID)
-	}
-
-	if len(reviewers) == 0 {
-		return nil, ErrNoCandidate
+	status := req.Status
+	if status == "" {
+		status = models.StatusOpen
 	}
 
 	var pr models.PullRequest
-	pr.AssignedReviewers = reviewers
+	pr.AssignedReviewers = []string{}
 
 	err = tx.QueryRowContext(ctx, `
-        INSERT INTO pull_requests (id, pull_request_name, author_id) 
-        VALUES ($1, $2, $3)
-		RETURNING id, pull_request_name, author_id, status`,
-		req.PullRequestID, req.PullRequestName, req.AuthorID).Scan(&pr.PullRequestID, &pr.PullRequestName, &pr.AuthorID, &pr.Status)
+        INSERT INTO pull_requests (id, pull_request_name, author_id, status, changed_files, labels,
+            repository, source_branch, target_branch, description, url) 
+        VALUES ($1, $2, $3, $4, COALESCE($5::text[], '{}'), COALESCE($6::text[], '{}'), $7, $8, $9, $10, $11)
+		RETURNING id, pull_request_name, author_id, status, changed_files, labels,
+            repository, source_branch, target_branch, description, url`,
+		req.PullRequestID, req.PullRequestName, req.AuthorID, status, pq.Array(req.ChangedFiles), pq.Array(req.Labels),
+		req.Repository, req.SourceBranch, req.TargetBranch, req.Description, req.URL).
+		Scan(&pr.PullRequestID, &pr.PullRequestName, &pr.AuthorID, &pr.Status, pq.Array(&pr.ChangedFiles), pq.Array(&pr.Labels),
+			&pr.Repository, &pr.SourceBranch, &pr.TargetBranch, &pr.Description, &pr.URL)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create pull request: %w", err)
 	}
 
-	for _, reviewerID := range reviewers {
-		_, err = tx.ExecContext(ctx, `
-            INSERT INTO pr_reviewers (pull_request_id, reviewer_id) 
-            VALUES ($1, $2)`,
-			req.PullRequestID, reviewerID)
+	// ревьюверы черновика назначаются, когда он готов к ревью
+	if status == models.StatusOpen {
+		sel, err := assignReviewers(ctx, tx, pick, prefs, req.PullRequestID, req.AuthorID, authorTeam, models.ReasonPRCreated)
 		if err != nil {
-			return nil, fmt.Errorf("failed to create reviewers: %w", err)
+			return nil, err
 		}
+		pr.AssignedReviewers, pr.FallbackReviewers, pr.SelectionReasons = sel.reviewers, sel.fromFallback, sel.reasons
+	}
+
+	if err := emitEvent(ctx, tx, models.EventPRCreated, pr); err != nil {
+		return nil, err
 	}
 
 	if err = tx.Commit(); err != nil {
@@ -103,76 +101,118 @@ func (r *PrRepository) CreatePullRequest(ctx context.Context, req models.PullReq
 	return &pr, nil
 }
 
-func (r *PrRepository) MergePullRequest(ctx context.Context, prID string) (*models.PullRequest, error) {
+// MergePullRequest отказывает в merge, если у PR не хватает одобрений, требуемых командой автора,
+// или есть неснятый CHANGES_REQUESTED. force обходит проверку; external означает, что PR уже
+// смержен в code host, и тоже не проверяется, а помечается merged_externally. force_merged
+// ставится только тогда, когда проверка действительно отказала бы.
+// MergePullRequest идемпотентен: повторный вызов для смерженного PR возвращает
+// сохранённый PR с исходным merged_at и merged = false.
+func (r *PrRepository) MergePullRequest(ctx context.Context, prID string, force, external bool) (*models.PullRequest, bool, error) {
 	tx, err := r.db.Begin()
 	if err != nil {
-		return nil, fmt.Errorf("failed to begin tx: %w", err)
+		return nil, false, fmt.Errorf("failed to begin tx: %w", err)
 	}
 	defer tx.Rollback()
 
-	var pr models.PullRequest
-	var mergedAt sql.NullTime
-
+	var status string
+	var requiredApprovals int
 	err = tx.QueryRowContext(ctx, `
-        UPDATE pull_requests 
-        SET status = 'MERGED', merged_at = CURRENT_TIMESTAMP 
-        WHERE id = $1 
-        RETURNING id, pull_request_name, author_id, status, merged_at`,
-		prID).Scan(&pr.PullRequestID, &pr.PullRequestName, &pr.AuthorID, &pr.Status, &pr.MergedAt)
-
+		SELECT p.status, COALESCE(s.required_approvals, 0)
+		FROM pull_requests p
+		JOIN users u ON u.id = p.author_id
+		LEFT JOIN team_settings s ON s.team_name = u.team_name
+		WHERE p.id = $1
+		FOR UPDATE OF p`,
+		prID).Scan(&status, &requiredApprovals)
 	if err == sql.ErrNoRows {
-		return nil, ErrNotFound
+		return nil, false, ErrNotFound
 	} else if err != nil {
-		return nil, fmt.Errorf("failed to merge pr: %w", err)
+		return nil, false, fmt.Errorf("failed to select required approvals: %w", err)
 	}
 
-	if mergedAt.Valid {
-		pr.MergedAt = &mergedAt.Time
+	switch status {
+	case models.StatusMerged:
+		pr, err := getPullRequest(ctx, tx, prID)
+		if err != nil {
+			return nil, false, err
+		}
+		return pr, false, nil
+	case models.StatusOpen:
+	default:
+		return nil, false, ErrInvalidTransition
 	}
 
-	rows, err := tx.QueryContext(ctx, `
-        SELECT reviewer_id FROM pr_reviewers 
-        WHERE pull_request_id = $1`, prID)
+	reviews, err := findLatestReviews(ctx, tx, prID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to select reviewers: %w", err)
+		return nil, false, err
 	}
-	defer rows.Close()
 
-	for rows.Next() {
-		var reviewerID string
-		if err := rows.Scan(&reviewerID); err != nil {
-			return nil, fmt.Errorf("failed to scan reviewers: %w", err)
+	approvals, changesRequested := 0, false
+	for _, review := range reviews {
+		switch review.State {
+		case models.ReviewApproved:
+			approvals++
+		case models.ReviewChangesRequested:
+			changesRequested = true
 		}
-		pr.AssignedReviewers = append(pr.AssignedReviewers, reviewerID)
+	}
+
+	blocked := changesRequested || approvals < requiredApprovals
+	if blocked && !force && !external {
+		return nil, false, ErrMergeBlocked
+	}
+
+	_, err = tx.ExecContext(ctx, `
+        UPDATE pull_requests 
+        SET status = 'MERGED', merged_at = CURRENT_TIMESTAMP, force_merged = $2, merged_externally = $3
+        WHERE id = $1`,
+		prID, blocked, external)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to merge pr: %w", err)
+	}
+
+	pr, err := getPullRequest(ctx, tx, prID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if err := emitEvent(ctx, tx, models.EventPRMerged, pr); err != nil {
+		return nil, false, err
 	}
 
 	if err = tx.Commit(); err != nil {
-		return nil, fmt.Errorf("failed to tx commit %w", err)
+		return nil, false, fmt.Errorf("failed to tx commit %w", err)
 	}
 
-	return &pr, nil
+	return pr, true, nil
 }
 
-func (r *PrRepository) ReassignReviewer(ctx context.Context, prID, oldUserID string) (*models.PullRequest, string, error) {
+func (r *PrRepository) ReassignReviewer(ctx context.Context, prID, oldUserID string, pick models.ReviewerPickFunc) (*models.PullRequest, string, error) {
 	tx, err := r.db.Begin()
 	if err != nil {
 		return nil, "", fmt.Errorf("failed to begin tx: %w", err)
 	}
 	defer tx.Rollback()
 
-	var status string
+	var status, authorID string
 	err = tx.QueryRowContext(ctx, `
-		SELECT status FROM pull_requests
-		WHERE id = $1`,
-		prID).Scan(&status)
+		SELECT status, author_id FROM pull_requests
+		WHERE id = $1
+		FOR UPDATE`,
+		prID).Scan(&status, &authorID)
 	if err == sql.ErrNoRows {
 		return nil, "", ErrNotFound
 	} else if err != nil {
 		return nil, "", fmt.Errorf("failed to check pr status: %w", err)
 	}
 
-	if status == "MERGED" {
+	switch status {
+	case models.StatusOpen:
+	case models.StatusMerged:
 		return nil, "", ErrPRMerged
+	default:
+		// у черновика и закрытого PR нет ревьюверов для замены
+		return nil, "", ErrInvalidTransition
 	}
 
 	var isAssigned bool
@@ -189,7 +229,7 @@ func (r *PrRepository) ReassignReviewer(ctx context.Context, prID, oldUserID str
 
 	var teamName string
 	err = tx.QueryRowContext(ctx, `
-		SELECT team_name FROM users
+		SELECT COALESCE(team_name, '') FROM users
 		WHERE id = $1 AND is_active = true`,
 		oldUserID).Scan(&teamName)
 	if err == sql.ErrNoRows {
@@ -198,60 +238,507 @@ func (r *PrRepository) ReassignReviewer(ctx context.Context, prID, oldUserID str
 		return nil, "", fmt.Errorf("failed to select reviewer team: %w", err)
 	}
 
-	var newReviewerID string
-	err = tx.QueryRowContext(ctx, `
-        SELECT u.id FROM users u
-        WHERE u.team_name = $1 
-        AND u.is_active = true
-        AND u.id != (SELECT author_id FROM pull_requests WHERE id = $2)
-        AND u.id NOT IN (SELECT reviewer_id FROM pr_reviewers WHERE pull_request_id = $2)
-        ORDER BY RANDOM() 
-        LIMIT 1`,
-		teamName, prID).Scan(&newReviewerID)
-
-	if err == sql.ErrNoRows {
-		return nil, "", ErrNoCandidate
-	} else if err != nil {
+	picked, fromFallback, err := pickReviewersWithFallback(ctx, tx, pick, teamName, prID, []string{authorID}, 1)
+	if err != nil {
 		return nil, "", fmt.Errorf("failed to find new reviewer: %w", err)
 	}
+	if len(picked) == 0 {
+		return nil, "", ErrNoCandidate
+	}
+	newReviewerID := picked[0]
 
 	_, err = tx.ExecContext(ctx, `
         UPDATE pr_reviewers 
-        SET reviewer_id = $1 
+        SET reviewer_id = $1, assigned_at = CURRENT_TIMESTAMP
         WHERE pull_request_id = $2 AND reviewer_id = $3`,
 		newReviewerID, prID, oldUserID)
 	if err != nil {
 		return nil, "", fmt.Errorf("failed to update reviewer: %w", err)
 	}
 
-	var pr models.PullRequest
+	err = recordAssignment(ctx, tx, prID, models.AssignmentReassigned, oldUserID, newReviewerID, models.ReasonManualReassign)
+	if err != nil {
+		return nil, "", err
+	}
+
+	pr, err := getPullRequest(ctx, tx, prID)
+	if err != nil {
+		return nil, "", err
+	}
+	// источник известен только для только что выбранного ревьювера
+	if len(fromFallback) > 0 {
+		pr.FallbackReviewers = fromFallback
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, "", fmt.Errorf("failed to tx commit: %w", err)
+	}
+
+	return pr, newReviewerID, nil
+}
+
+func (r *PrRepository) GetPullRequestStatus(ctx context.Context, prID string) (string, error) {
+	var status string
+	err := r.db.QueryRowContext(ctx, "SELECT status FROM pull_requests WHERE id = $1", prID).Scan(&status)
+	if err == sql.ErrNoRows {
+		return "", ErrNotFound
+	} else if err != nil {
+		return "", fmt.Errorf("failed to select pr status: %w", err)
+	}
+	return status, nil
+}
+
+// OpenPullRequest переводит PR из статуса from (DRAFT или CLOSED) в OPEN и назначает ревьюверов.
+func (r *PrRepository) OpenPullRequest(ctx context.Context, prID, from string, pick models.ReviewerPickFunc, prefs []models.ReviewerPreference) (*models.PullRequest, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	var status, authorID, authorTeam string
 	err = tx.QueryRowContext(ctx, `
-        SELECT id, pull_request_name, author_id, status, created_at 
-        FROM pull_requests
-		WHERE id = $1`, prID).
-		Scan(&pr.PullRequestID, &pr.PullRequestName, &pr.AuthorID, &pr.Status, &pr.CreatedAt)
+		SELECT p.status, p.author_id, COALESCE(u.team_name, '')
+		FROM pull_requests p
+		JOIN users u ON u.id = p.author_id
+		WHERE p.id = $1
+		FOR UPDATE OF p`,
+		prID).Scan(&status, &authorID, &authorTeam)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to select pr: %w", err)
+	}
+
+	if status != from {
+		return nil, ErrInvalidTransition
+	}
+
+	reason := models.ReasonPRReopened
+	if from == models.StatusDraft {
+		reason = models.ReasonReadyForReview
+	}
+
+	sel, err := assignReviewers(ctx, tx, pick, prefs, prID, authorID, authorTeam, reason)
 	if err != nil {
-		return nil, "", fmt.Errorf("failed to select pr: %w", err)
+		return nil, err
 	}
 
-	rows, err := tx.QueryContext(ctx, `
-        SELECT reviewer_id FROM pr_reviewers WHERE pull_request_id = $1`, prID)
+	_, err = tx.ExecContext(ctx, `
+		UPDATE pull_requests
+		SET status = 'OPEN', closed_at = NULL
+		WHERE id = $1`, prID)
 	if err != nil {
-		return nil, "", fmt.Errorf("failed to select new reviewers: %w", err)
+		return nil, fmt.Errorf("failed to open pr: %w", err)
 	}
-	defer rows.Close()
 
+	pr, err := getPullRequest(ctx, tx, prID)
+	if err != nil {
+		return nil, err
+	}
+	pr.FallbackReviewers, pr.SelectionReasons = sel.fromFallback, sel.reasons
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to tx commit: %w", err)
+	}
+
+	return pr, nil
+}
+
+// UpdatePullRequest меняет описание PR: название, репозиторий, ветки, описание, ссылку и метки.
+// Статус и ревьюверы не меняются.
+func (r *PrRepository) UpdatePullRequest(ctx context.Context, prID string, upd models.PullRequestUpdate) (*models.PullRequest, error) {
+	var labels any
+	if upd.Labels != nil {
+		labels = pq.Array(*upd.Labels)
+	}
+
+	res, err := r.db.ExecContext(ctx, `
+		UPDATE pull_requests
+		SET pull_request_name = COALESCE($2, pull_request_name),
+		    repository = COALESCE($3, repository),
+		    source_branch = COALESCE($4, source_branch),
+		    target_branch = COALESCE($5, target_branch),
+		    description = COALESCE($6, description),
+		    url = COALESCE($7, url),
+		    labels = COALESCE($8::text[], labels)
+		WHERE id = $1`,
+		prID, upd.PullRequestName, upd.Repository, upd.SourceBranch, upd.TargetBranch, upd.Description, upd.URL, labels)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update pr: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return nil, ErrNotFound
+	}
+
+	return getPullRequest(ctx, r.db, prID)
+}
+
+// AddPullRequestLabels добавляет метки PR; уже имеющиеся метки не дублируются.
+// Уже назначенные ревьюверы не меняются.
+func (r *PrRepository) AddPullRequestLabels(ctx context.Context, prID string, labels []string) (*models.PullRequest, error) {
+	return r.updatePullRequestLabels(ctx, prID, `ARRAY(SELECT DISTINCT l FROM unnest(labels || $2::text[]) l ORDER BY l)`, labels)
+}
+
+// RemovePullRequestLabels снимает метки PR; отсутствующие метки игнорируются.
+func (r *PrRepository) RemovePullRequestLabels(ctx context.Context, prID string, labels []string) (*models.PullRequest, error) {
+	return r.updatePullRequestLabels(ctx, prID, `ARRAY(SELECT l FROM unnest(labels) l WHERE l <> ALL($2::text[]) ORDER BY l)`, labels)
+}
+
+func (r *PrRepository) updatePullRequestLabels(ctx context.Context, prID, expr string, labels []string) (*models.PullRequest, error) {
+	res, err := r.db.ExecContext(ctx, `
+		UPDATE pull_requests
+		SET labels = `+expr+`
+		WHERE id = $1`, prID, pq.Array(labels))
+	if err != nil {
+		return nil, fmt.Errorf("failed to update pr labels: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return nil, ErrNotFound
+	}
+
+	return getPullRequest(ctx, r.db, prID)
+}
+
+// ClosePullRequest закрывает PR без merge и освобождает его ревьюверов.
+func (r *PrRepository) ClosePullRequest(ctx context.Context, prID, from string) (*models.PullRequest, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx, `
+		UPDATE pull_requests
+		SET status = 'CLOSED', closed_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND status = $2`,
+		prID, from)
+	if err != nil {
+		return nil, fmt.Errorf("failed to close pr: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("failed to close pr: %w", err)
+	}
+	if affected == 0 {
+		return nil, ErrInvalidTransition
+	}
+
+	if err := releaseReviewers(ctx, tx, prID, models.ReasonPRClosed); err != nil {
+		return nil, err
+	}
+
+	pr, err := getPullRequest(ctx, tx, prID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to tx commit: %w", err)
+	}
+
+	return pr, nil
+}
+
+// ConvertToDraft возвращает открытый PR в черновик и освобождает его ревьюверов.
+func (r *PrRepository) ConvertToDraft(ctx context.Context, prID string) (*models.PullRequest, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx, `
+		UPDATE pull_requests
+		SET status = 'DRAFT'
+		WHERE id = $1 AND status = 'OPEN'`, prID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert pr to draft: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert pr to draft: %w", err)
+	}
+	if affected == 0 {
+		return nil, ErrInvalidTransition
+	}
+
+	if err := releaseReviewers(ctx, tx, prID, models.ReasonConvertedToDraft); err != nil {
+		return nil, err
+	}
+
+	pr, err := getPullRequest(ctx, tx, prID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to tx commit: %w", err)
+	}
+
+	return pr, nil
+}
+
+// releaseReviewers снимает всех ревьюверов PR с записью в журнал назначений.
+func releaseReviewers(ctx context.Context, tx *sql.Tx, prID, reason string) error {
+	rows, err := tx.QueryContext(ctx, "DELETE FROM pr_reviewers WHERE pull_request_id = $1 RETURNING reviewer_id", prID)
+	if err != nil {
+		return fmt.Errorf("failed to release reviewers: %w", err)
+	}
+
+	var released []string
 	for rows.Next() {
 		var reviewerID string
 		if err := rows.Scan(&reviewerID); err != nil {
-			return nil, "", fmt.Errorf("failed to scan reviewers: %w", err)
+			rows.Close()
+			return fmt.Errorf("failed to scan released reviewer: %w", err)
 		}
-		pr.AssignedReviewers = append(pr.AssignedReviewers, reviewerID)
+		released = append(released, reviewerID)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("rows error: %w", err)
 	}
 
-	if err = tx.Commit(); err != nil {
-		return nil, "", fmt.Errorf("failed to tx commit: %w", err)
+	for _, reviewerID := range released {
+		if err := recordAssignment(ctx, tx, prID, models.AssignmentRemoved, reviewerID, "", reason); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// likeEscaper экранирует спецсимволы шаблона LIKE.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+const selectPullRequest = `
+        SELECT p.id, p.pull_request_name, p.author_id, p.status, p.force_merged, p.merged_externally, p.created_at, p.merged_at, p.closed_at,
+            p.changed_files, p.labels, p.repository, p.source_branch, p.target_branch, p.description, p.url
+        FROM pull_requests p`
+
+func scanPullRequest(row rowScanner) (*models.PullRequest, error) {
+	var pr models.PullRequest
+	var createdAt, mergedAt, closedAt sql.NullTime
+
+	err := row.Scan(&pr.PullRequestID, &pr.PullRequestName, &pr.AuthorID, &pr.Status, &pr.ForceMerged, &pr.MergedExternally, &createdAt, &mergedAt, &closedAt,
+		pq.Array(&pr.ChangedFiles), pq.Array(&pr.Labels), &pr.Repository, &pr.SourceBranch, &pr.TargetBranch, &pr.Description, &pr.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	if createdAt.Valid {
+		pr.CreatedAt = &createdAt.Time
+	}
+	if mergedAt.Valid {
+		pr.MergedAt = &mergedAt.Time
+	}
+	if closedAt.Valid {
+		pr.ClosedAt = &closedAt.Time
+	}
+
+	return &pr, nil
+}
+
+func getPullRequest(ctx context.Context, q queryer, prID string) (*models.PullRequest, error) {
+	pr, err := scanPullRequest(q.QueryRowContext(ctx, selectPullRequest+" WHERE p.id = $1", prID))
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to select pr: %w", err)
+	}
+
+	if err := loadPullRequestDetails(ctx, q, []*models.PullRequest{pr}); err != nil {
+		return nil, err
+	}
+
+	return pr, nil
+}
+
+// loadPullRequestDetails дополняет PR ревьюверами, последними вердиктами и состоянием
+// выгрузки в code host — по одному запросу на таблицу для всех PR сразу.
+func loadPullRequestDetails(ctx context.Context, q queryer, prs []*models.PullRequest) error {
+	if len(prs) == 0 {
+		return nil
+	}
+
+	ids := make([]string, 0, len(prs))
+	byID := make(map[string]*models.PullRequest, len(prs))
+	for _, pr := range prs {
+		pr.AssignedReviewers = []string{}
+		ids = append(ids, pr.PullRequestID)
+		byID[pr.PullRequestID] = pr
+	}
+
+	rows, err := q.QueryContext(ctx, `
+        SELECT pull_request_id, reviewer_id FROM pr_reviewers
+        WHERE pull_request_id = ANY($1)
+        ORDER BY pull_request_id, assigned_at, reviewer_id`, pq.Array(ids))
+	if err != nil {
+		return fmt.Errorf("failed to select reviewers: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var prID, reviewerID string
+		if err := rows.Scan(&prID, &reviewerID); err != nil {
+			return fmt.Errorf("failed to scan reviewers: %w", err)
+		}
+		byID[prID].AssignedReviewers = append(byID[prID].AssignedReviewers, reviewerID)
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("rows error: %w", err)
+	}
+	rows.Close()
+
+	rows, err = q.QueryContext(ctx, `
+        SELECT DISTINCT ON (rv.pull_request_id, rv.reviewer_id) rv.pull_request_id, rv.reviewer_id, rv.state, rv.comment, rv.created_at
+        FROM reviews rv
+        JOIN pr_reviewers pr ON pr.pull_request_id = rv.pull_request_id AND pr.reviewer_id = rv.reviewer_id
+        WHERE rv.pull_request_id = ANY($1)
+        ORDER BY rv.pull_request_id, rv.reviewer_id, rv.created_at DESC, rv.id DESC`, pq.Array(ids))
+	if err != nil {
+		return fmt.Errorf("failed to select reviews: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var prID string
+		var review models.Review
+		if err := rows.Scan(&prID, &review.ReviewerID, &review.State, &review.Comment, &review.SubmittedAt); err != nil {
+			return fmt.Errorf("failed to scan review: %w", err)
+		}
+		byID[prID].Reviews = append(byID[prID].Reviews, review)
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("rows error: %w", err)
+	}
+	rows.Close()
+
+	rows, err = q.QueryContext(ctx, `
+        SELECT pull_request_id, status, attempts, last_error, next_attempt_at, synced_at
+        FROM reviewer_syncs
+        WHERE pull_request_id = ANY($1)`, pq.Array(ids))
+	if err != nil {
+		return fmt.Errorf("failed to select reviewer syncs: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var prID string
+		sync, err := scanReviewerSync(rows, &prID)
+		if err != nil {
+			return fmt.Errorf("failed to scan reviewer sync: %w", err)
+		}
+		byID[prID].ReviewerSync = sync
+	}
+
+	return rows.Err()
+}
+
+func (r *PrRepository) GetPullRequest(ctx context.Context, prID string) (*models.PullRequest, error) {
+	return getPullRequest(ctx, r.db, prID)
+}
+
+// ListPullRequests возвращает страницу PR по фильтру и общее число подходящих PR.
+func (r *PrRepository) ListPullRequests(ctx context.Context, filter models.PullRequestFilter) ([]models.PullRequest, int, error) {
+	var conds []string
+	var args []any
+	where := func(cond string, arg any) {
+		args = append(args, arg)
+		conds = append(conds, fmt.Sprintf(cond, len(args)))
+	}
+
+	if filter.Status != "" {
+		where("p.status = $%d", filter.Status)
+	}
+	if filter.AuthorID != "" {
+		where("p.author_id = $%d", filter.AuthorID)
+	}
+	if filter.ReviewerID != "" {
+		where("EXISTS (SELECT 1 FROM pr_reviewers r WHERE r.pull_request_id = p.id AND r.reviewer_id = $%d)", filter.ReviewerID)
+	}
+	if filter.TeamName != "" {
+		where("u.team_name = $%d", filter.TeamName)
+	}
+	if filter.Repository != "" {
+		where("p.repository = $%d", filter.Repository)
+	}
+	if filter.SourceBranch != "" {
+		where("p.source_branch = $%d", filter.SourceBranch)
+	}
+	if filter.TargetBranch != "" {
+		where("p.target_branch = $%d", filter.TargetBranch)
+	}
+	if len(filter.Labels) > 0 {
+		where("p.labels @> $%d::text[]", pq.Array(filter.Labels))
+	}
+	if filter.Search != "" {
+		// % и _ в запросе ищутся как обычные символы
+		where(`(p.pull_request_name || ' ' || p.description) ILIKE '%%' || $%d || '%%' ESCAPE '\'`, likeEscaper.Replace(filter.Search))
+	}
+	if filter.CreatedFrom != nil {
+		where("p.created_at >= $%d", *filter.CreatedFrom)
+	}
+	if filter.CreatedTo != nil {
+		where("p.created_at < $%d", *filter.CreatedTo)
+	}
+	if filter.MergedFrom != nil {
+		where("p.merged_at >= $%d", *filter.MergedFrom)
+	}
+	if filter.MergedTo != nil {
+		where("p.merged_at < $%d", *filter.MergedTo)
+	}
+
+	whereSQL := ""
+	if len(conds) > 0 {
+		whereSQL = "WHERE " + strings.Join(conds, " AND ")
+	}
+
+	var total int
+	err := r.db.QueryRowContext(ctx, `
+        SELECT COUNT(*)
+        FROM pull_requests p
+        JOIN users u ON u.id = p.author_id
+        `+whereSQL, args...).Scan(&total)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count pull requests: %w", err)
+	}
+
+	args = append(args, filter.Limit, filter.Offset)
+	rows, err := r.db.QueryContext(ctx, fmt.Sprintf(selectPullRequest+`
+        JOIN users u ON u.id = p.author_id
+        %s
+        ORDER BY p.created_at DESC, p.id
+        LIMIT $%d OFFSET $%d`, whereSQL, len(args)-1, len(args)), args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to select pull requests: %w", err)
+	}
+	defer rows.Close()
+
+	var page []*models.PullRequest
+	for rows.Next() {
+		pr, err := scanPullRequest(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan pull requests: %w", err)
+		}
+		page = append(page, pr)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("rows error: %w", err)
+	}
+	rows.Close()
+
+	if err := loadPullRequestDetails(ctx, r.db, page); err != nil {
+		return nil, 0, err
+	}
+
+	prs := make([]models.PullRequest, 0, len(page))
+	for _, pr := range page {
+		prs = append(prs, *pr)
 	}
 
-	return &pr, newReviewerID, nil
+	return prs, total, nil
 }
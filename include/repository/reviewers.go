@@ -0,0 +1,276 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+
+	"github.com/RomanKovalev007/pull_request_service/include/models"
+	"github.com/lib/pq"
+)
+
+func findReviewerCandidates(ctx context.Context, tx *sql.Tx, teamName, prID string, exclude []string) ([]models.ReviewerCandidate, error) {
+	rows, err := tx.QueryContext(ctx, `
+        SELECT u.id, u.team_name, u.tags, COUNT(p.id) AS open_reviews, MAX(r.assigned_at) AS last_assigned_at
+        FROM users u
+        LEFT JOIN pr_reviewers r ON r.reviewer_id = u.id
+        LEFT JOIN pull_requests p ON p.id = r.pull_request_id AND p.status = 'OPEN'
+        WHERE u.team_name = $1
+        AND u.is_active = true
+        AND u.id <> ALL($2)
+        AND NOT EXISTS (
+            SELECT 1
+            FROM user_availability a
+            WHERE a.user_id = u.id
+            AND a.starts_at <= CURRENT_TIMESTAMP
+            AND a.ends_at > CURRENT_TIMESTAMP
+        )
+        AND u.id NOT IN (
+            SELECT reviewer_id
+            FROM pr_reviewers
+            WHERE pull_request_id = $3
+        )
+        GROUP BY u.id, u.team_name, u.tags, u.review_capacity
+        HAVING u.review_capacity IS NULL OR COUNT(p.id) < u.review_capacity
+        ORDER BY u.id`,
+		teamName, pq.Array(exclude), prID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select reviewer candidates: %w", err)
+	}
+	defer rows.Close()
+
+	var candidates []models.ReviewerCandidate
+	for rows.Next() {
+		var c models.ReviewerCandidate
+		var lastAssignedAt sql.NullTime
+		if err := rows.Scan(&c.UserID, &c.TeamName, pq.Array(&c.Tags), &c.OpenReviews, &lastAssignedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan reviewer candidate: %w", err)
+		}
+		if lastAssignedAt.Valid {
+			c.LastAssignedAt = &lastAssignedAt.Time
+		}
+		candidates = append(candidates, c)
+	}
+
+	return candidates, rows.Err()
+}
+
+func pickReviewers(ctx context.Context, tx *sql.Tx, pick models.ReviewerPickFunc, teamName, prID string, exclude []string, count int) ([]string, error) {
+	candidates, err := findReviewerCandidates(ctx, tx, teamName, prID, exclude)
+	if err != nil {
+		return nil, err
+	}
+
+	return pickFromCandidates(pick, teamName, candidates, count)
+}
+
+// pickFromCandidates вызывает стратегию и проверяет, что она вернула не больше count
+// ревьюверов из переданных кандидатов.
+func pickFromCandidates(pick models.ReviewerPickFunc, teamName string, candidates []models.ReviewerCandidate, count int) ([]string, error) {
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+
+	known := make(map[string]bool, len(candidates))
+	for _, c := range candidates {
+		known[c.UserID] = true
+	}
+
+	var reviewers []string
+	for _, id := range pick(teamName, candidates, count) {
+		if !known[id] || len(reviewers) == count {
+			return nil, fmt.Errorf("selector returned unexpected reviewer %q", id)
+		}
+		known[id] = false
+		reviewers = append(reviewers, id)
+	}
+
+	return reviewers, nil
+}
+
+// pickReviewersWithFallback добирает ревьюверов из резервных команд по порядку,
+// если команда teamName не может дать count кандидатов. Возвращает ревьюверов
+// и команды тех из них, кто взят из резервных команд.
+func pickReviewersWithFallback(ctx context.Context, tx *sql.Tx, pick models.ReviewerPickFunc, teamName, prID string, exclude []string, count int) ([]string, map[string]string, error) {
+	reviewers, err := pickReviewers(ctx, tx, pick, teamName, prID, exclude, count)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(reviewers) >= count {
+		return reviewers, nil, nil
+	}
+
+	fallbacks, err := findFallbackTeams(ctx, tx, teamName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var fromFallback map[string]string
+	for _, fallback := range fallbacks {
+		if len(reviewers) >= count {
+			break
+		}
+
+		excluded := append(slices.Clone(exclude), reviewers...)
+		picked, err := pickReviewers(ctx, tx, pick, fallback, prID, excluded, count-len(reviewers))
+		if err != nil {
+			return nil, nil, err
+		}
+
+		for _, id := range picked {
+			if fromFallback == nil {
+				fromFallback = make(map[string]string)
+			}
+			fromFallback[id] = fallback
+		}
+		reviewers = append(reviewers, picked...)
+	}
+
+	return reviewers, fromFallback, nil
+}
+
+// reviewerSelection — выбранные ревьюверы, команды тех из них, кто взят из резервных
+// команд, и причина выбора каждого.
+type reviewerSelection struct {
+	reviewers    []string
+	fromFallback map[string]string
+	reasons      map[string]string
+}
+
+func (sel *reviewerSelection) add(ids []string, fallbackTeam, reason string) {
+	for _, id := range ids {
+		if fallbackTeam != "" {
+			if sel.fromFallback == nil {
+				sel.fromFallback = make(map[string]string)
+			}
+			sel.fromFallback[id] = fallbackTeam
+		}
+		sel.reasons[id] = reason
+	}
+	sel.reviewers = append(sel.reviewers, ids...)
+}
+
+// pickPreferredReviewers выбирает ревьюверов по правилам prefs в порядке их следования:
+// для каждого правила — подходящих кандидатов из команды teamName и её резервных команд.
+// Оставшиеся места заполняются по обычным правилам.
+func pickPreferredReviewers(ctx context.Context, tx *sql.Tx, pick models.ReviewerPickFunc, prefs []models.ReviewerPreference, teamName, prID string, exclude []string, count int) (*reviewerSelection, error) {
+	sel := &reviewerSelection{reasons: make(map[string]string)}
+
+	var teams []string
+	if len(prefs) > 0 {
+		fallbacks, err := findFallbackTeams(ctx, tx, teamName)
+		if err != nil {
+			return nil, err
+		}
+		teams = append([]string{teamName}, fallbacks...)
+	}
+
+	for _, pref := range prefs {
+		limit := count - len(sel.reviewers)
+		if pref.Limit > 0 {
+			limit = min(limit, pref.Limit)
+		}
+
+		for i, team := range teams {
+			if limit <= 0 {
+				break
+			}
+
+			candidates, err := findReviewerCandidates(ctx, tx, team, prID, append(slices.Clone(exclude), sel.reviewers...))
+			if err != nil {
+				return nil, err
+			}
+			matching := slices.DeleteFunc(candidates, func(c models.ReviewerCandidate) bool { return !pref.Match(c) })
+
+			picked, err := pickFromCandidates(pick, team, matching, limit)
+			if err != nil {
+				return nil, err
+			}
+
+			fallbackTeam := ""
+			if i > 0 {
+				fallbackTeam = team
+			}
+			sel.add(picked, fallbackTeam, pref.Reason)
+			limit -= len(picked)
+		}
+	}
+
+	if rest := count - len(sel.reviewers); rest > 0 {
+		picked, fromFallback, err := pickReviewersWithFallback(ctx, tx, pick, teamName, prID, append(slices.Clone(exclude), sel.reviewers...), rest)
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range picked {
+			sel.add([]string{id}, fromFallback[id], models.SelectedByStrategy)
+		}
+	}
+
+	return sel, nil
+}
+
+func findFallbackTeams(ctx context.Context, q queryer, teamName string) ([]string, error) {
+	rows, err := q.QueryContext(ctx, `
+        SELECT fallback_team
+        FROM team_fallbacks
+        WHERE team_name = $1
+        ORDER BY position`, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select fallback teams: %w", err)
+	}
+	defer rows.Close()
+
+	teams := []string{}
+	for rows.Next() {
+		var team string
+		if err := rows.Scan(&team); err != nil {
+			return nil, fmt.Errorf("failed to scan fallback team: %w", err)
+		}
+		teams = append(teams, team)
+	}
+
+	return teams, rows.Err()
+}
+
+// assignReviewers назначает ревьюверов на PR по настройкам команды автора, начиная
+// с кандидатов, подходящих под prefs. Если не набирается минимальный кворум, возвращает ErrNoCandidate.
+func assignReviewers(ctx context.Context, tx *sql.Tx, pick models.ReviewerPickFunc, prefs []models.ReviewerPreference, prID, authorID, authorTeam, reason string) (*reviewerSelection, error) {
+	var reviewerCount, minReviewers int
+	err := tx.QueryRowContext(ctx, `
+		SELECT COALESCE(MAX(reviewer_count), $2), COALESCE(MAX(min_reviewers), $3)
+		FROM team_settings
+		WHERE team_name = $1`,
+		authorTeam, defaultReviewerCount, defaultMinReviewers).Scan(&reviewerCount, &minReviewers)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select team settings: %w", err)
+	}
+
+	sel, err := pickPreferredReviewers(ctx, tx, pick, prefs, authorTeam, prID, []string{authorID}, reviewerCount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select reviewers: %w", err)
+	}
+
+	if len(sel.reviewers) < minReviewers {
+		return nil, ErrNoCandidate
+	}
+
+	for _, reviewerID := range sel.reviewers {
+		_, err = tx.ExecContext(ctx, `
+            INSERT INTO pr_reviewers (pull_request_id, reviewer_id) 
+            VALUES ($1, $2)`,
+			prID, reviewerID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create reviewers: %w", err)
+		}
+		if err := recordAssignment(ctx, tx, prID, models.AssignmentAssigned, "", reviewerID, reason); err != nil {
+			return nil, err
+		}
+	}
+
+	if sel.reviewers == nil {
+		sel.reviewers = []string{}
+	}
+
+	return sel, nil
+}
@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/RomanKovalev007/pull_request_service/include/models"
+)
+
+func (r *PrRepository) SubmitReview(ctx context.Context, prID string, review models.Review) (*models.PullRequest, *models.Review, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	var status string
+	err = tx.QueryRowContext(ctx, `
+		SELECT status FROM pull_requests
+		WHERE id = $1
+		FOR UPDATE`,
+		prID).Scan(&status)
+	if err == sql.ErrNoRows {
+		return nil, nil, ErrNotFound
+	} else if err != nil {
+		return nil, nil, fmt.Errorf("failed to check pr status: %w", err)
+	}
+
+	switch status {
+	case models.StatusOpen:
+	case models.StatusMerged:
+		return nil, nil, ErrPRMerged
+	default:
+		return nil, nil, ErrInvalidTransition
+	}
+
+	var isAssigned bool
+	err = tx.QueryRowContext(ctx, `
+        SELECT EXISTS(SELECT 1 FROM pr_reviewers
+		WHERE pull_request_id = $1 AND reviewer_id = $2)`,
+		prID, review.ReviewerID).Scan(&isAssigned)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to check is reviewer: %w", err)
+	}
+	if !isAssigned {
+		return nil, nil, ErrNotAssigned
+	}
+
+	var result models.Review
+	err = tx.QueryRowContext(ctx, `
+        INSERT INTO reviews (pull_request_id, reviewer_id, state, comment)
+        VALUES ($1, $2, $3, $4)
+        RETURNING reviewer_id, state, comment, created_at`,
+		prID, review.ReviewerID, review.State, review.Comment).
+		Scan(&result.ReviewerID, &result.State, &result.Comment, &result.SubmittedAt)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create review: %w", err)
+	}
+
+	pr, err := getPullRequest(ctx, tx, prID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, nil, fmt.Errorf("failed to tx commit: %w", err)
+	}
+
+	return pr, &result, nil
+}
+
+// findLatestReviews возвращает последний вердикт каждого назначенного ревьювера.
+func findLatestReviews(ctx context.Context, q queryer, prID string) ([]models.Review, error) {
+	rows, err := q.QueryContext(ctx, `
+        SELECT DISTINCT ON (rv.reviewer_id) rv.reviewer_id, rv.state, rv.comment, rv.created_at
+        FROM reviews rv
+        JOIN pr_reviewers pr ON pr.pull_request_id = rv.pull_request_id AND pr.reviewer_id = rv.reviewer_id
+        WHERE rv.pull_request_id = $1
+        ORDER BY rv.reviewer_id, rv.created_at DESC, rv.id DESC`, prID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select reviews: %w", err)
+	}
+	defer rows.Close()
+
+	var reviews []models.Review
+	for rows.Next() {
+		var review models.Review
+		if err := rows.Scan(&review.ReviewerID, &review.State, &review.Comment, &review.SubmittedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan review: %w", err)
+		}
+		reviews = append(reviews, review)
+	}
+
+	return reviews, rows.Err()
+}
@@ -0,0 +1,356 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/RomanKovalev007/pull_request_service/include/models"
+	"github.com/lib/pq"
+)
+
+// ExportSnapshot выгружает все данные в одном согласованном снимке.
+func (r *AdminRepository) ExportSnapshot(ctx context.Context) (*models.Snapshot, error) {
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	snap := models.Snapshot{Version: models.SnapshotVersion, ExportedAt: time.Now().UTC()}
+
+	if snap.Teams, err = exportTeams(ctx, tx); err != nil {
+		return nil, err
+	}
+	if snap.Users, err = exportUsers(ctx, tx); err != nil {
+		return nil, err
+	}
+	if snap.PullRequests, err = exportPullRequests(ctx, tx); err != nil {
+		return nil, err
+	}
+	if snap.Availability, err = exportAvailability(ctx, tx); err != nil {
+		return nil, err
+	}
+	if snap.AssignmentEvents, err = findAssignmentEvents(ctx, tx, ""); err != nil {
+		return nil, err
+	}
+
+	return &snap, nil
+}
+
+func exportTeams(ctx context.Context, tx *sql.Tx) ([]models.SnapshotTeam, error) {
+	rows, err := tx.QueryContext(ctx, `
+        SELECT t.team_name, t.created_at, s.reviewer_count, s.min_reviewers, s.required_approvals, COALESCE(c.content, '')
+        FROM teams t
+        LEFT JOIN team_settings s ON s.team_name = t.team_name
+        LEFT JOIN team_codeowners c ON c.team_name = t.team_name
+        ORDER BY t.team_name`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select teams: %w", err)
+	}
+	defer rows.Close()
+
+	teams := []models.SnapshotTeam{}
+	for rows.Next() {
+		var team models.SnapshotTeam
+		var createdAt sql.NullTime
+		var reviewerCount, minReviewers, requiredApprovals sql.NullInt64
+		if err := rows.Scan(&team.TeamName, &createdAt, &reviewerCount, &minReviewers, &requiredApprovals, &team.CodeOwners); err != nil {
+			return nil, fmt.Errorf("failed to scan team: %w", err)
+		}
+		team.CreatedAt = nullTime(createdAt)
+		if reviewerCount.Valid {
+			team.Settings = &models.TeamSettings{
+				TeamName:          team.TeamName,
+				ReviewerCount:     int(reviewerCount.Int64),
+				MinReviewers:      int(minReviewers.Int64),
+				RequiredApprovals: int(requiredApprovals.Int64),
+			}
+		}
+		teams = append(teams, team)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+	rows.Close()
+
+	for i := range teams {
+		fallbacks, err := findFallbackTeams(ctx, tx, teams[i].TeamName)
+		if err != nil {
+			return nil, err
+		}
+		if len(fallbacks) == 0 {
+			continue
+		}
+		if teams[i].Settings == nil {
+			teams[i].Settings = &models.TeamSettings{
+				TeamName:      teams[i].TeamName,
+				ReviewerCount: defaultReviewerCount,
+				MinReviewers:  defaultMinReviewers,
+			}
+		}
+		teams[i].Settings.FallbackTeams = fallbacks
+	}
+
+	return teams, nil
+}
+
+func exportUsers(ctx context.Context, tx *sql.Tx) ([]models.SnapshotUser, error) {
+	rows, err := tx.QueryContext(ctx, `
+        SELECT id, username, COALESCE(team_name, ''), is_active, review_capacity, created_at, updated_at, tags
+        FROM users
+        ORDER BY id`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select users: %w", err)
+	}
+	defer rows.Close()
+
+	users := []models.SnapshotUser{}
+	for rows.Next() {
+		var user models.SnapshotUser
+		var createdAt, updatedAt sql.NullTime
+		if err := rows.Scan(&user.UserID, &user.Username, &user.TeamName, &user.IsActive, &user.ReviewCapacity, &createdAt, &updatedAt, pq.Array(&user.Tags)); err != nil {
+			return nil, fmt.Errorf("failed to scan user: %w", err)
+		}
+		user.CreatedAt, user.UpdatedAt = nullTime(createdAt), nullTime(updatedAt)
+		users = append(users, user)
+	}
+
+	return users, rows.Err()
+}
+
+func exportPullRequests(ctx context.Context, tx *sql.Tx) ([]models.SnapshotPullRequest, error) {
+	rows, err := tx.QueryContext(ctx, `
+        SELECT id, pull_request_name, author_id, status, force_merged, merged_externally, created_at, merged_at, closed_at, changed_files, labels,
+            repository, source_branch, target_branch, description, url
+        FROM pull_requests
+        ORDER BY created_at, id`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select pull requests: %w", err)
+	}
+	defer rows.Close()
+
+	prs := []models.SnapshotPullRequest{}
+	index := make(map[string]int)
+	for rows.Next() {
+		pr := models.SnapshotPullRequest{Reviewers: []models.SnapshotReviewer{}, Reviews: []models.Review{}}
+		var createdAt, mergedAt, closedAt sql.NullTime
+		if err := rows.Scan(&pr.PullRequestID, &pr.PullRequestName, &pr.AuthorID, &pr.Status, &pr.ForceMerged, &pr.MergedExternally, &createdAt, &mergedAt, &closedAt, pq.Array(&pr.ChangedFiles), pq.Array(&pr.Labels),
+			&pr.Repository, &pr.SourceBranch, &pr.TargetBranch, &pr.Description, &pr.URL); err != nil {
+			return nil, fmt.Errorf("failed to scan pull request: %w", err)
+		}
+		pr.CreatedAt, pr.MergedAt, pr.ClosedAt = nullTime(createdAt), nullTime(mergedAt), nullTime(closedAt)
+		index[pr.PullRequestID] = len(prs)
+		prs = append(prs, pr)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+	rows.Close()
+
+	reviewerRows, err := tx.QueryContext(ctx, `
+        SELECT pull_request_id, reviewer_id, assigned_at
+        FROM pr_reviewers
+        ORDER BY pull_request_id, assigned_at, reviewer_id`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select reviewers: %w", err)
+	}
+	defer reviewerRows.Close()
+
+	for reviewerRows.Next() {
+		var prID string
+		var reviewer models.SnapshotReviewer
+		var assignedAt sql.NullTime
+		if err := reviewerRows.Scan(&prID, &reviewer.ReviewerID, &assignedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan reviewer: %w", err)
+		}
+		reviewer.AssignedAt = nullTime(assignedAt)
+		prs[index[prID]].Reviewers = append(prs[index[prID]].Reviewers, reviewer)
+	}
+	if err := reviewerRows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+	reviewerRows.Close()
+
+	reviewRows, err := tx.QueryContext(ctx, `
+        SELECT pull_request_id, reviewer_id, state, comment, created_at
+        FROM reviews
+        ORDER BY id`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select reviews: %w", err)
+	}
+	defer reviewRows.Close()
+
+	for reviewRows.Next() {
+		var prID string
+		var review models.Review
+		if err := reviewRows.Scan(&prID, &review.ReviewerID, &review.State, &review.Comment, &review.SubmittedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan review: %w", err)
+		}
+		prs[index[prID]].Reviews = append(prs[index[prID]].Reviews, review)
+	}
+
+	return prs, reviewRows.Err()
+}
+
+func exportAvailability(ctx context.Context, tx *sql.Tx) ([]models.Availability, error) {
+	rows, err := tx.QueryContext(ctx, `
+        SELECT id, user_id, starts_at, ends_at, reason, reassign_reviews, reassigned_at
+        FROM user_availability
+        ORDER BY id`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select availability: %w", err)
+	}
+	defer rows.Close()
+
+	windows := []models.Availability{}
+	for rows.Next() {
+		a, err := scanAvailability(rows)
+		if err != nil {
+			return nil, err
+		}
+		windows = append(windows, *a)
+	}
+
+	return windows, rows.Err()
+}
+
+// RestoreSnapshot загружает выгрузку в пустую базу, иначе возвращает ErrNotEmpty.
+func (r *AdminRepository) RestoreSnapshot(ctx context.Context, snap models.Snapshot) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	var empty bool
+	err = tx.QueryRowContext(ctx, `
+        SELECT NOT EXISTS(SELECT 1 FROM teams)
+           AND NOT EXISTS(SELECT 1 FROM users)
+           AND NOT EXISTS(SELECT 1 FROM pull_requests)`).Scan(&empty)
+	if err != nil {
+		return fmt.Errorf("failed to check database is empty: %w", err)
+	}
+	if !empty {
+		return ErrNotEmpty
+	}
+
+	for _, team := range snap.Teams {
+		_, err := tx.ExecContext(ctx, `
+            INSERT INTO teams (team_name, created_at)
+            VALUES ($1, COALESCE($2, CURRENT_TIMESTAMP))`, team.TeamName, team.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to restore team %s: %w", team.TeamName, err)
+		}
+	}
+
+	for _, team := range snap.Teams {
+		if team.Settings == nil {
+			continue
+		}
+		_, err := tx.ExecContext(ctx, `
+            INSERT INTO team_settings (team_name, reviewer_count, min_reviewers, required_approvals)
+            VALUES ($1, $2, $3, $4)`,
+			team.TeamName, team.Settings.ReviewerCount, team.Settings.MinReviewers, team.Settings.RequiredApprovals)
+		if err != nil {
+			return fmt.Errorf("failed to restore settings of %s: %w", team.TeamName, err)
+		}
+		for i, fallback := range team.Settings.FallbackTeams {
+			_, err := tx.ExecContext(ctx, `
+                INSERT INTO team_fallbacks (team_name, fallback_team, position)
+                VALUES ($1, $2, $3)`, team.TeamName, fallback, i)
+			if err != nil {
+				return fmt.Errorf("failed to restore fallback teams of %s: %w", team.TeamName, err)
+			}
+		}
+	}
+
+	for _, team := range snap.Teams {
+		if team.CodeOwners == "" {
+			continue
+		}
+		_, err := tx.ExecContext(ctx, `
+            INSERT INTO team_codeowners (team_name, content)
+            VALUES ($1, $2)`, team.TeamName, team.CodeOwners)
+		if err != nil {
+			return fmt.Errorf("failed to restore codeowners of %s: %w", team.TeamName, err)
+		}
+	}
+
+	for _, user := range snap.Users {
+		_, err := tx.ExecContext(ctx, `
+            INSERT INTO users (id, username, team_name, is_active, review_capacity, created_at, updated_at, tags)
+            VALUES ($1, $2, NULLIF($3, ''), $4, $5, COALESCE($6, CURRENT_TIMESTAMP), COALESCE($7, CURRENT_TIMESTAMP), COALESCE($8::text[], '{}'))`,
+			user.UserID, user.Username, user.TeamName, user.IsActive, user.ReviewCapacity, user.CreatedAt, user.UpdatedAt, pq.Array(user.Tags))
+		if err != nil {
+			return fmt.Errorf("failed to restore user %s: %w", user.UserID, err)
+		}
+	}
+
+	for _, pr := range snap.PullRequests {
+		_, err := tx.ExecContext(ctx, `
+            INSERT INTO pull_requests (id, pull_request_name, author_id, status, force_merged, merged_externally, created_at, merged_at, closed_at, changed_files, labels,
+                repository, source_branch, target_branch, description, url)
+            VALUES ($1, $2, $3, $4, $5, $6, COALESCE($7, CURRENT_TIMESTAMP), $8, $9, COALESCE($10::text[], '{}'), COALESCE($11::text[], '{}'),
+                $12, $13, $14, $15, $16)`,
+			pr.PullRequestID, pr.PullRequestName, pr.AuthorID, pr.Status, pr.ForceMerged, pr.MergedExternally, pr.CreatedAt, pr.MergedAt, pr.ClosedAt, pq.Array(pr.ChangedFiles), pq.Array(pr.Labels),
+			pr.Repository, pr.SourceBranch, pr.TargetBranch, pr.Description, pr.URL)
+		if err != nil {
+			return fmt.Errorf("failed to restore pull request %s: %w", pr.PullRequestID, err)
+		}
+
+		for _, reviewer := range pr.Reviewers {
+			_, err := tx.ExecContext(ctx, `
+                INSERT INTO pr_reviewers (pull_request_id, reviewer_id, assigned_at)
+                VALUES ($1, $2, COALESCE($3, CURRENT_TIMESTAMP))`,
+				pr.PullRequestID, reviewer.ReviewerID, reviewer.AssignedAt)
+			if err != nil {
+				return fmt.Errorf("failed to restore reviewers of %s: %w", pr.PullRequestID, err)
+			}
+		}
+
+		for _, review := range pr.Reviews {
+			_, err := tx.ExecContext(ctx, `
+                INSERT INTO reviews (pull_request_id, reviewer_id, state, comment, created_at)
+                VALUES ($1, $2, $3, $4, $5)`,
+				pr.PullRequestID, review.ReviewerID, review.State, review.Comment, review.SubmittedAt)
+			if err != nil {
+				return fmt.Errorf("failed to restore reviews of %s: %w", pr.PullRequestID, err)
+			}
+		}
+	}
+
+	for _, a := range snap.Availability {
+		_, err := tx.ExecContext(ctx, `
+            INSERT INTO user_availability (user_id, starts_at, ends_at, reason, reassign_reviews, reassigned_at)
+            VALUES ($1, $2, $3, $4, $5, $6)`,
+			a.UserID, a.StartsAt, a.EndsAt, a.Reason, a.ReassignReviews, a.ReassignedAt)
+		if err != nil {
+			return fmt.Errorf("failed to restore availability of %s: %w", a.UserID, err)
+		}
+	}
+
+	for _, e := range snap.AssignmentEvents {
+		_, err := tx.ExecContext(ctx, `
+            INSERT INTO assignment_events (pull_request_id, action, actor, old_reviewer_id, new_reviewer_id, reason, created_at)
+            VALUES ($1, $2, $3, NULLIF($4, ''), NULLIF($5, ''), $6, $7)`,
+			e.PullRequestID, e.Action, e.Actor, e.OldReviewerID, e.NewReviewerID, e.Reason, e.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to restore assignment events of %s: %w", e.PullRequestID, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to tx commit: %w", err)
+	}
+
+	return nil
+}
+
+func nullTime(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	return &t.Time
+}
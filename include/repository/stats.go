@@ -18,7 +18,7 @@ func NewStatsRepository(db *sql.DB) *StatsRepository {
 
 func (r *StatsRepository) GetUserStats(ctx context.Context) ([]models.UserStat, error) {
 	query := `
-        SELECT u.id, u.username, u.team_name, COUNT(pr.reviewer_id) as assignment_count
+        SELECT u.id, u.username, COALESCE(u.team_name, ''), COUNT(pr.reviewer_id) as assignment_count
         FROM users u
         LEFT JOIN pr_reviewers pr ON u.id = pr.reviewer_id
         WHERE u.is_active = true
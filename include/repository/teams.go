@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	"github.com/RomanKovalev007/pull_request_service/include/models"
+	"github.com/lib/pq"
 )
 
 type TeamRepository struct {
@@ -16,7 +17,9 @@ func NewTeamRepository(db *sql.DB) *TeamRepository {
 	return &TeamRepository{db: db}
 }
 
-func (r *TeamRepository) CreateTeam(ctx context.Context, team models.Team) (*models.Team, error) {
+// CreateTeam создаёт команду и её участников. Открытые ревью существующих пользователей,
+// перешедших из другой команды или деактивированных, переназначаются, как в AddTeamMembers.
+func (r *TeamRepository) CreateTeam(ctx context.Context, team models.Team, pick models.ReviewerPickFunc) (*models.Team, error) {
 	tx, err := r.db.Begin()
 	if err != nil {
 		return nil, fmt.Errorf("failed to begin tx: %w", err)
@@ -43,21 +46,14 @@ func (r *TeamRepository) CreateTeam(ctx context.Context, team models.Team) (*mod
 	for _, member := range team.Members {
 		var result_member models.TeamMember
 
-		var exist bool
-		err = tx.QueryRowContext(ctx, "SELECT EXISTS(SELECT 1 FROM users WHERE id = $1)", member.UserID).Scan(&exist)
-
-		if err == nil && exist {
-			_, err = tx.ExecContext(ctx, `
-                DELETE FROM pr_reviewers 
-                WHERE reviewer_id = $1 
-                AND pull_request_id IN (
-                    SELECT pull_request_id FROM pull_requests WHERE status = 'OPEN'
-                )`,
-				member.UserID)
-			if err != nil {
-				return nil, fmt.Errorf("failed to remove user from open PR reviews: %w", err)
-			}
-		} else if err != nil && err != sql.ErrNoRows {
+		var oldTeam sql.NullString
+		var wasActive bool
+		err = tx.QueryRowContext(ctx, `
+            SELECT team_name, is_active FROM users
+            WHERE id = $1
+            FOR UPDATE`, member.UserID).Scan(&oldTeam, &wasActive)
+		existed := err == nil
+		if err != nil && err != sql.ErrNoRows {
 			return nil, fmt.Errorf("failed to check user exists: %w", err)
 		}
 
@@ -72,6 +68,17 @@ func (r *TeamRepository) CreateTeam(ctx context.Context, team models.Team) (*mod
 			return nil, fmt.Errorf("failed to create user: %w", err)
 		}
 		result_team.Members = append(result_team.Members, result_member)
+
+		// команда новая, поэтому у пользователя с командой она всегда меняется
+		if existed && oldTeam.Valid {
+			reason := models.ReasonTeamChanged
+			if wasActive && !member.IsActive {
+				reason = models.ReasonUserDeactivated
+			}
+			if _, err := reassignUserReviews(ctx, tx, member.UserID, oldTeam.String, reason, pick); err != nil {
+				return nil, fmt.Errorf("failed to reassign reviews of %s: %w", member.UserID, err)
+			}
+		}
 	}
 
 	err = tx.Commit()
@@ -82,10 +89,22 @@ func (r *TeamRepository) CreateTeam(ctx context.Context, team models.Team) (*mod
 }
 
 func (r *TeamRepository) GetTeam(ctx context.Context, teamName string) (*models.Team, error) {
-	var team models.Team
-	team.TeamName = teamName
+	return getTeam(ctx, r.db, teamName)
+}
 
-	rows, err := r.db.QueryContext(ctx, `
+func getTeam(ctx context.Context, q queryer, teamName string) (*models.Team, error) {
+	var exists bool
+	err := q.QueryRowContext(ctx, "SELECT EXISTS(SELECT 1 FROM teams WHERE team_name = $1)", teamName).Scan(&exists)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check team exists: %w", err)
+	}
+	if !exists {
+		return nil, ErrNotFound
+	}
+
+	team := models.Team{TeamName: teamName, Members: []models.TeamMember{}}
+
+	rows, err := q.QueryContext(ctx, `
         SELECT id, username, is_active 
         FROM users 
         WHERE team_name = $1 
@@ -103,9 +122,395 @@ func (r *TeamRepository) GetTeam(ctx context.Context, teamName string) (*models.
 		team.Members = append(team.Members, member)
 	}
 
-	if len(team.Members) == 0 {
+	return &team, rows.Err()
+}
+
+func (r *TeamRepository) ListTeams(ctx context.Context) ([]models.Team, error) {
+	rows, err := r.db.QueryContext(ctx, `
+        SELECT t.team_name, u.id, u.username, u.is_active
+        FROM teams t
+        LEFT JOIN users u ON u.team_name = t.team_name
+        ORDER BY t.team_name, u.id`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select teams: %w", err)
+	}
+	defer rows.Close()
+
+	teams := []models.Team{}
+	for rows.Next() {
+		var teamName string
+		var userID, username sql.NullString
+		var isActive sql.NullBool
+		if err := rows.Scan(&teamName, &userID, &username, &isActive); err != nil {
+			return nil, fmt.Errorf("failed to scan team: %w", err)
+		}
+
+		if len(teams) == 0 || teams[len(teams)-1].TeamName != teamName {
+			teams = append(teams, models.Team{TeamName: teamName, Members: []models.TeamMember{}})
+		}
+		if userID.Valid {
+			team := &teams[len(teams)-1]
+			team.Members = append(team.Members, models.TeamMember{UserID: userID.String, Username: username.String, IsActive: isActive.Bool})
+		}
+	}
+
+	return teams, rows.Err()
+}
+
+func (r *TeamRepository) RenameTeam(ctx context.Context, teamName, newTeamName string) (*models.Team, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	var exists bool
+	err = tx.QueryRowContext(ctx, "SELECT EXISTS(SELECT 1 FROM teams WHERE team_name = $1)", newTeamName).Scan(&exists)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check team exists: %w", err)
+	}
+	if exists {
+		return nil, ErrTeamExists
+	}
+
+	// пользователи, настройки и резервные команды переименовываются через ON UPDATE CASCADE
+	res, err := tx.ExecContext(ctx, "UPDATE teams SET team_name = $2 WHERE team_name = $1", teamName, newTeamName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to rename team: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return nil, ErrNotFound
+	}
+
+	team, err := getTeam(ctx, tx, newTeamName)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to tx commit: %w", err)
+	}
+
+	return team, nil
+}
+
+// DeleteTeam удаляет команду, её участники остаются без команды. С открытыми ревью
+// участников поступает согласно policy:
+//   - reassign — ревью передаются резервным командам, без замены ревьювер снимается;
+//     если после этого у какого-либо PR не осталось ни одного ревьювера, удаление
+//     отклоняется с ErrTeamReviewsOrphaned;
+//   - drop — участники снимаются со всех открытых ревью;
+//   - reject — при наличии открытых ревью удаление отклоняется с ErrTeamHasOpenReviews.
+func (r *TeamRepository) DeleteTeam(ctx context.Context, teamName, policy string, pick models.ReviewerPickFunc) ([]string, []models.ReviewHandoff, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	var locked string
+	err = tx.QueryRowContext(ctx, "SELECT team_name FROM teams WHERE team_name = $1 FOR UPDATE", teamName).Scan(&locked)
+	if err == sql.ErrNoRows {
+		return nil, nil, ErrNotFound
+	} else if err != nil {
+		return nil, nil, fmt.Errorf("failed to select team: %w", err)
+	}
+
+	if policy == models.OpenReviewsReject {
+		var openReviews bool
+		err = tx.QueryRowContext(ctx, `
+            SELECT EXISTS(
+                SELECT 1
+                FROM pr_reviewers r
+                JOIN users u ON u.id = r.reviewer_id
+                JOIN pull_requests p ON p.id = r.pull_request_id
+                WHERE u.team_name = $1 AND p.status = 'OPEN'
+            )`, teamName).Scan(&openReviews)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to check open reviews: %w", err)
+		}
+		if openReviews {
+			return nil, nil, ErrTeamHasOpenReviews
+		}
+	}
+
+	rows, err := tx.QueryContext(ctx, `
+        UPDATE users
+        SET team_name = NULL, updated_at = CURRENT_TIMESTAMP
+        WHERE team_name = $1
+        RETURNING id`, teamName)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to release team members: %w", err)
+	}
+
+	released := []string{}
+	for rows.Next() {
+		var userID string
+		if err := rows.Scan(&userID); err != nil {
+			rows.Close()
+			return nil, nil, fmt.Errorf("failed to scan team member: %w", err)
+		}
+		released = append(released, userID)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	report := []models.ReviewHandoff{}
+	var dropped []string
+	for _, userID := range released {
+		var handoffs []models.ReviewHandoff
+		if policy == models.OpenReviewsDrop {
+			handoffs, err = dropUserReviews(ctx, tx, userID, models.ReasonTeamDeleted)
+		} else {
+			// участники уже без команды, а команда ещё существует, поэтому замена
+			// берётся из её резервных команд
+			handoffs, err = reassignUserReviews(ctx, tx, userID, teamName, models.ReasonTeamDeleted, pick)
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to hand off reviews of %s: %w", userID, err)
+		}
+		for _, handoff := range handoffs {
+			if handoff.Action == models.ReviewDropped {
+				dropped = append(dropped, handoff.PullRequestID)
+			}
+			handoff.ReviewerID = userID
+			report = append(report, handoff)
+		}
+	}
+
+	if policy == models.OpenReviewsReassign && len(dropped) > 0 {
+		var orphaned bool
+		err = tx.QueryRowContext(ctx, `
+            SELECT EXISTS(
+                SELECT 1 FROM unnest($1::text[]) AS d(pull_request_id)
+                WHERE NOT EXISTS (SELECT 1 FROM pr_reviewers r WHERE r.pull_request_id = d.pull_request_id)
+            )`, pq.Array(dropped)).Scan(&orphaned)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to check orphaned reviews: %w", err)
+		}
+		// PR остался бы совсем без ревьюверов — транзакция откатывается
+		if orphaned {
+			return nil, nil, ErrTeamReviewsOrphaned
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM teams WHERE team_name = $1", teamName); err != nil {
+		return nil, nil, fmt.Errorf("failed to delete team: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, nil, fmt.Errorf("failed to tx commit: %w", err)
+	}
+
+	return released, report, nil
+}
+
+// AddTeamMembers добавляет пользователей в команду, создавая новых. Открытые ревью
+// пользователей, перешедших из другой команды или деактивированных, переназначаются.
+func (r *TeamRepository) AddTeamMembers(ctx context.Context, teamName string, members []models.TeamMember, pick models.ReviewerPickFunc) (*models.Team, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	var locked string
+	err = tx.QueryRowContext(ctx, "SELECT team_name FROM teams WHERE team_name = $1 FOR SHARE", teamName).Scan(&locked)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to select team: %w", err)
+	}
+
+	for _, member := range members {
+		var oldTeam sql.NullString
+		var wasActive bool
+		err = tx.QueryRowContext(ctx, `
+            SELECT team_name, is_active FROM users
+            WHERE id = $1
+            FOR UPDATE`, member.UserID).Scan(&oldTeam, &wasActive)
+		existed := err == nil
+		if err != nil && err != sql.ErrNoRows {
+			return nil, fmt.Errorf("failed to select user: %w", err)
+		}
+
+		_, err = tx.ExecContext(ctx, `
+            INSERT INTO users (id, username, team_name, is_active) 
+            VALUES ($1, $2, $3, $4)
+            ON CONFLICT (id) 
+            DO UPDATE SET username = $2, team_name = $3, is_active = $4, updated_at = CURRENT_TIMESTAMP`,
+			member.UserID, member.Username, teamName, member.IsActive)
+		if err != nil {
+			return nil, fmt.Errorf("failed to add team member: %w", err)
+		}
+
+		if existed && wasActive && !member.IsActive {
+			if err := emitUserDeactivated(ctx, tx, member.UserID); err != nil {
+				return nil, err
+			}
+		}
+
+		if !existed || !oldTeam.Valid {
+			continue
+		}
+		if oldTeam.String != teamName || (wasActive && !member.IsActive) {
+			reason := models.ReasonTeamChanged
+			if oldTeam.String == teamName {
+				reason = models.ReasonUserDeactivated
+			}
+			if _, err := reassignUserReviews(ctx, tx, member.UserID, oldTeam.String, reason, pick); err != nil {
+				return nil, fmt.Errorf("failed to reassign reviews of %s: %w", member.UserID, err)
+			}
+		}
+	}
+
+	team, err := getTeam(ctx, tx, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to tx commit: %w", err)
+	}
+
+	return team, nil
+}
+
+// RemoveTeamMembers исключает пользователей из команды, их открытые ревью переназначаются.
+func (r *TeamRepository) RemoveTeamMembers(ctx context.Context, teamName string, userIDs []string, pick models.ReviewerPickFunc) (*models.Team, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, userID := range userIDs {
+		res, err := tx.ExecContext(ctx, `
+            UPDATE users
+            SET team_name = NULL, updated_at = CURRENT_TIMESTAMP
+            WHERE id = $1 AND team_name = $2`, userID, teamName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to remove team member: %w", err)
+		}
+		if n, _ := res.RowsAffected(); n == 0 {
+			return nil, ErrNotFound
+		}
+
+		if _, err := reassignUserReviews(ctx, tx, userID, teamName, models.ReasonTeamChanged, pick); err != nil {
+			return nil, fmt.Errorf("failed to reassign reviews of %s: %w", userID, err)
+		}
+	}
+
+	team, err := getTeam(ctx, tx, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to tx commit: %w", err)
+	}
+
+	return team, nil
+}
+
+func (r *TeamRepository) GetTeamSettings(ctx context.Context, teamName string) (*models.TeamSettings, error) {
+	settings := models.TeamSettings{TeamName: teamName}
+
+	err := r.db.QueryRowContext(ctx, `
+        SELECT COALESCE(s.reviewer_count, $2), COALESCE(s.min_reviewers, $3), COALESCE(s.required_approvals, 0)
+        FROM teams t
+        LEFT JOIN team_settings s ON s.team_name = t.team_name
+        WHERE t.team_name = $1`,
+		teamName, defaultReviewerCount, defaultMinReviewers).Scan(&settings.ReviewerCount, &settings.MinReviewers, &settings.RequiredApprovals)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to select team settings: %w", err)
+	}
+
+	settings.FallbackTeams, err = findFallbackTeams(ctx, r.db, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	return &settings, nil
+}
+
+// SetTeamSettings меняет переданные в upd настройки команды, остальные сохраняются.
+// Резервные команды переписываются, только если upd.FallbackTeams задан.
+func (r *TeamRepository) SetTeamSettings(ctx context.Context, teamName string, upd models.TeamSettingsUpdate) (*models.TeamSettings, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	var exists bool
+	err = tx.QueryRowContext(ctx, "SELECT EXISTS(SELECT 1 FROM teams WHERE team_name = $1)", teamName).Scan(&exists)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check team exists: %w", err)
+	}
+	if !exists {
 		return nil, ErrNotFound
 	}
 
-	return &team, nil
+	result := models.TeamSettings{TeamName: teamName}
+	err = tx.QueryRowContext(ctx, `
+        INSERT INTO team_settings (team_name, reviewer_count, min_reviewers, required_approvals)
+        VALUES ($1, COALESCE($2::int, $5), COALESCE($3::int, $6), COALESCE($4::int, 0))
+        ON CONFLICT (team_name)
+        DO UPDATE SET reviewer_count = COALESCE($2::int, team_settings.reviewer_count),
+            min_reviewers = COALESCE($3::int, team_settings.min_reviewers),
+            required_approvals = COALESCE($4::int, team_settings.required_approvals),
+            updated_at = CURRENT_TIMESTAMP
+        RETURNING reviewer_count, min_reviewers, required_approvals`,
+		teamName, upd.ReviewerCount, upd.MinReviewers, upd.RequiredApprovals, defaultReviewerCount, defaultMinReviewers).
+		Scan(&result.ReviewerCount, &result.MinReviewers, &result.RequiredApprovals)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save team settings: %w", err)
+	}
+
+	if upd.FallbackTeams == nil {
+		result.FallbackTeams, err = findFallbackTeams(ctx, tx, teamName)
+		if err != nil {
+			return nil, err
+		}
+		if err = tx.Commit(); err != nil {
+			return nil, fmt.Errorf("failed to tx commit: %w", err)
+		}
+		return &result, nil
+	}
+
+	_, err = tx.ExecContext(ctx, "DELETE FROM team_fallbacks WHERE team_name = $1", teamName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to clear fallback teams: %w", err)
+	}
+
+	result.FallbackTeams = []string{}
+	for i, fallback := range *upd.FallbackTeams {
+		err = tx.QueryRowContext(ctx, "SELECT EXISTS(SELECT 1 FROM teams WHERE team_name = $1)", fallback).Scan(&exists)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check fallback team exists: %w", err)
+		}
+		if !exists {
+			return nil, ErrNotFound
+		}
+
+		_, err = tx.ExecContext(ctx, `
+            INSERT INTO team_fallbacks (team_name, fallback_team, position)
+            VALUES ($1, $2, $3)`,
+			teamName, fallback, i)
+		if err != nil {
+			return nil, fmt.Errorf("failed to save fallback team: %w", err)
+		}
+		result.FallbackTeams = append(result.FallbackTeams, fallback)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to tx commit: %w", err)
+	}
+
+	return &result, nil
 }
@@ -4,8 +4,10 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"time"
 
 	"github.com/RomanKovalev007/pull_request_service/include/models"
+	"github.com/lib/pq"
 )
 
 type UserRepository struct {
@@ -16,66 +18,253 @@ func NewUserRepository(db *sql.DB) *UserRepository {
 	return &UserRepository{db: db}
 }
 
-func (r *UserRepository) GetUserPullRequests(ctx context.Context, userID string) ([]models.PullRequestShort, error) {
+// GetUserPullRequests возвращает страницу PR ревьювера и курсор следующей страницы,
+// nil — если страница последняя.
+func (r *UserRepository) GetUserPullRequests(ctx context.Context, userID string, filter models.ReviewFilter) ([]models.PullRequestShort, *models.PageCursor, error) {
 	tx, err := r.db.Begin()
 	if err != nil {
-		return nil, fmt.Errorf("failed to begin tx: %w", err)
+		return nil, nil, fmt.Errorf("failed to begin tx: %w", err)
 	}
+	defer tx.Rollback()
 
 	var exists bool
 	err = tx.QueryRowContext(ctx, "SELECT EXISTS(SELECT 1 FROM users WHERE id = $1)", userID).Scan(&exists)
 	if err != nil {
-		return nil, fmt.Errorf("failed to check user exists: %w", err)
+		return nil, nil, fmt.Errorf("failed to check user exists: %w", err)
 	}
 	if !exists {
-		return nil, ErrNotFound
+		return nil, nil, ErrNotFound
 	}
 
-	var prs []models.PullRequestShort
+	var afterCreatedAt *time.Time
+	var afterID string
+	if filter.After != nil {
+		afterCreatedAt, afterID = &filter.After.CreatedAt, filter.After.PullRequestID
+	}
 
 	rows, err := tx.QueryContext(ctx, `
-        SELECT p.id, p.pull_request_name, p.author_id, p.status
+        SELECT p.id, p.pull_request_name, p.author_id, p.status, p.created_at
         FROM pull_requests p
         JOIN pr_reviewers pr ON p.id = pr.pull_request_id
         WHERE pr.reviewer_id = $1
-        ORDER BY p.created_at DESC`,
-		userID)
+          AND ($2 = '' OR p.status = $2)
+          AND ($3::timestamptz IS NULL OR p.created_at >= $3)
+          AND ($4::timestamp IS NULL OR (p.created_at, p.id) < ($4::timestamp, $5))
+        ORDER BY p.created_at DESC, p.id DESC
+        LIMIT $6`,
+		userID, filter.Status, filter.Since, afterCreatedAt, afterID, filter.Limit+1)
 	if err != nil {
-		return nil, fmt.Errorf("failed to select user pull requests: %w", err)
+		return nil, nil, fmt.Errorf("failed to select user pull requests: %w", err)
 	}
 	defer rows.Close()
 
+	prs := []models.PullRequestShort{}
+	var createdAt []time.Time
 	for rows.Next() {
 		var pr models.PullRequestShort
-		if err := rows.Scan(&pr.PullRequestID, &pr.PullRequestName, &pr.AuthorID, &pr.Status); err != nil {
-			return nil, fmt.Errorf("failed to scan user pull requests: %w", err)
+		var prCreatedAt time.Time
+		if err := rows.Scan(&pr.PullRequestID, &pr.PullRequestName, &pr.AuthorID, &pr.Status, &prCreatedAt); err != nil {
+			return nil, nil, fmt.Errorf("failed to scan user pull requests: %w", err)
 		}
 		prs = append(prs, pr)
+		createdAt = append(createdAt, prCreatedAt)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("rows error: %w", err)
 	}
 
 	err = tx.Commit()
 	if err != nil {
+		return nil, nil, fmt.Errorf("failed to tx commit: %w", err)
+	}
+
+	if len(prs) <= filter.Limit {
+		return prs, nil, nil
+	}
+
+	prs = prs[:filter.Limit]
+	last := len(prs) - 1
+	return prs, &models.PageCursor{CreatedAt: createdAt[last], PullRequestID: prs[last].PullRequestID}, nil
+}
+
+const selectUser = `
+        SELECT id, username, COALESCE(team_name, ''), is_active, review_capacity, tags
+        FROM users`
+
+func (r *UserRepository) GetUser(ctx context.Context, userID string) (*models.User, error) {
+	return getUser(ctx, r.db, userID)
+}
+
+func getUser(ctx context.Context, q queryer, userID string) (*models.User, error) {
+	var user models.User
+	err := q.QueryRowContext(ctx, selectUser+" WHERE id = $1", userID).
+		Scan(&user.UserID, &user.Username, &user.TeamName, &user.IsActive, &user.ReviewCapacity, pq.Array(&user.Tags))
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to select user: %w", err)
+	}
+	return &user, nil
+}
+
+func (r *UserRepository) ListUsers(ctx context.Context, filter models.UserFilter) ([]models.User, error) {
+	rows, err := r.db.QueryContext(ctx, selectUser+`
+        WHERE ($1 = '' OR team_name = $1)
+          AND ($2::boolean IS NULL OR is_active = $2)
+        ORDER BY id`, filter.TeamName, filter.IsActive)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select users: %w", err)
+	}
+	defer rows.Close()
+
+	users := []models.User{}
+	for rows.Next() {
+		var user models.User
+		if err := rows.Scan(&user.UserID, &user.Username, &user.TeamName, &user.IsActive, &user.ReviewCapacity, pq.Array(&user.Tags)); err != nil {
+			return nil, fmt.Errorf("failed to scan user: %w", err)
+		}
+		users = append(users, user)
+	}
+
+	return users, rows.Err()
+}
+
+func (r *UserRepository) CreateUser(ctx context.Context, user models.User) (*models.User, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	var exists bool
+	err = tx.QueryRowContext(ctx, "SELECT EXISTS(SELECT 1 FROM teams WHERE team_name = $1)", user.TeamName).Scan(&exists)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check team exists: %w", err)
+	}
+	if !exists {
+		return nil, ErrNotFound
+	}
+
+	res, err := tx.ExecContext(ctx, `
+        INSERT INTO users (id, username, team_name, is_active, review_capacity, tags)
+        VALUES ($1, $2, $3, $4, $5, COALESCE($6::text[], '{}'))
+        ON CONFLICT (id) DO NOTHING`,
+		user.UserID, user.Username, user.TeamName, user.IsActive, user.ReviewCapacity, pq.Array(user.Tags))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create user: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return nil, ErrUserExists
+	}
+
+	created, err := getUser(ctx, tx, user.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
 		return nil, fmt.Errorf("failed to tx commit: %w", err)
 	}
 
-	return prs, nil
+	return created, nil
+}
+
+// UpdateUser меняет указанные поля пользователя. Открытые ревью пользователя, сменившего
+// команду, переназначаются так же, как в MoveUserTeam с режимом reassign, а ревью
+// деактивированного — так же, как в SetUserIsActive. Отчёт перечисляет затронутые PR.
+func (r *UserRepository) UpdateUser(ctx context.Context, userID string, upd models.UserUpdate, pick models.ReviewerPickFunc) (*models.User, []models.ReviewHandoff, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	var oldTeam sql.NullString
+	var wasActive bool
+	err = tx.QueryRowContext(ctx, "SELECT team_name, is_active FROM users WHERE id = $1 FOR UPDATE", userID).Scan(&oldTeam, &wasActive)
+	if err == sql.ErrNoRows {
+		return nil, nil, ErrNotFound
+	} else if err != nil {
+		return nil, nil, fmt.Errorf("failed to select user: %w", err)
+	}
+
+	if upd.TeamName != nil {
+		var exists bool
+		err = tx.QueryRowContext(ctx, "SELECT EXISTS(SELECT 1 FROM teams WHERE team_name = $1)", *upd.TeamName).Scan(&exists)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to check team exists: %w", err)
+		}
+		if !exists {
+			return nil, nil, ErrNotFound
+		}
+	}
+
+	var user models.User
+	err = tx.QueryRowContext(ctx, `
+        UPDATE users
+        SET username = COALESCE($2, username),
+            team_name = COALESCE($3, team_name),
+            is_active = COALESCE($4, is_active),
+            updated_at = CURRENT_TIMESTAMP
+        WHERE id = $1
+        RETURNING id, username, COALESCE(team_name, ''), is_active, review_capacity, tags`,
+		userID, upd.Username, upd.TeamName, upd.IsActive).
+		Scan(&user.UserID, &user.Username, &user.TeamName, &user.IsActive, &user.ReviewCapacity, pq.Array(&user.Tags))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to update user: %w", err)
+	}
+
+	report := []models.ReviewHandoff{}
+	deactivated := wasActive && !user.IsActive
+	switch {
+	case deactivated:
+		report, err = reassignUserReviews(ctx, tx, userID, oldTeam.String, models.ReasonUserDeactivated, pick)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to reassign user reviews: %w", err)
+		}
+	case oldTeam.String != user.TeamName:
+		report, err = handOffMovedUserReviews(ctx, tx, userID, oldTeam.String, models.HandoffReassign, pick)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if deactivated {
+		if err := emitUserDeactivated(ctx, tx, userID); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, nil, fmt.Errorf("failed to tx commit: %w", err)
+	}
+
+	return &user, report, nil
 }
 
-func (r *UserRepository) SetUserIsActive(ctx context.Context, userID string, isActive bool) (*models.User, error) {
+func (r *UserRepository) SetUserIsActive(ctx context.Context, userID string, isActive bool, pick models.ReviewerPickFunc) (*models.User, error) {
 	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
 	defer tx.Rollback()
 
+	var wasActive bool
+	err = tx.QueryRowContext(ctx, "SELECT is_active FROM users WHERE id = $1 FOR UPDATE", userID).Scan(&wasActive)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to select user: %w", err)
+	}
+
 	var user models.User
 
 	err = tx.QueryRowContext(ctx, `
         UPDATE users
         SET is_active = $1, updated_at = CURRENT_TIMESTAMP
         WHERE id = $2
-        RETURNING id, username, team_name, is_active`,
-		isActive, userID).Scan(&user.UserID, &user.Username, &user.TeamName, &user.IsActive)
+        RETURNING id, username, COALESCE(team_name, ''), is_active, review_capacity, tags`,
+		isActive, userID).Scan(&user.UserID, &user.Username, &user.TeamName, &user.IsActive, &user.ReviewCapacity, pq.Array(&user.Tags))
 
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -85,11 +274,16 @@ func (r *UserRepository) SetUserIsActive(ctx context.Context, userID string, isA
 	}
 
 	if !isActive {
-		err := r.reassignUserReviews(ctx, tx, userID, user.TeamName)
+		_, err := reassignUserReviews(ctx, tx, userID, user.TeamName, models.ReasonUserDeactivated, pick)
 		if err != nil {
 			return nil, fmt.Errorf("failed to reassign user reviews: %w", err)
 		}
 
+		if wasActive {
+			if err := emitUserDeactivated(ctx, tx, userID); err != nil {
+				return nil, err
+			}
+		}
 	}
 
 	if err := tx.Commit(); err != nil {
@@ -99,37 +293,189 @@ func (r *UserRepository) SetUserIsActive(ctx context.Context, userID string, isA
 	return &user, nil
 }
 
-func (r *UserRepository) reassignUserReviews(ctx context.Context, tx *sql.Tx, userID, teamName string) error {
+func (r *UserRepository) SetUserCapacity(ctx context.Context, userID string, capacity *int) (*models.User, error) {
+	var user models.User
+
+	err := r.db.QueryRowContext(ctx, `
+        UPDATE users
+        SET review_capacity = $1, updated_at = CURRENT_TIMESTAMP
+        WHERE id = $2
+        RETURNING id, username, COALESCE(team_name, ''), is_active, review_capacity, tags`,
+		capacity, userID).Scan(&user.UserID, &user.Username, &user.TeamName, &user.IsActive, &user.ReviewCapacity, pq.Array(&user.Tags))
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to set user review capacity: %w", err)
+	}
+
+	return &user, nil
+}
+
+// AddUserTags добавляет пользователю теги навыков; уже имеющиеся теги не дублируются.
+func (r *UserRepository) AddUserTags(ctx context.Context, userID string, tags []string) (*models.User, error) {
+	return r.updateUserTags(ctx, userID, `ARRAY(SELECT DISTINCT t FROM unnest(tags || $2::text[]) t ORDER BY t)`, tags)
+}
+
+// RemoveUserTags снимает с пользователя теги навыков; отсутствующие теги игнорируются.
+func (r *UserRepository) RemoveUserTags(ctx context.Context, userID string, tags []string) (*models.User, error) {
+	return r.updateUserTags(ctx, userID, `ARRAY(SELECT t FROM unnest(tags) t WHERE t <> ALL($2::text[]) ORDER BY t)`, tags)
+}
+
+func (r *UserRepository) updateUserTags(ctx context.Context, userID, expr string, tags []string) (*models.User, error) {
+	var user models.User
+
+	err := r.db.QueryRowContext(ctx, `
+        UPDATE users
+        SET tags = `+expr+`, updated_at = CURRENT_TIMESTAMP
+        WHERE id = $1
+        RETURNING id, username, COALESCE(team_name, ''), is_active, review_capacity, tags`,
+		userID, pq.Array(tags)).Scan(&user.UserID, &user.Username, &user.TeamName, &user.IsActive, &user.ReviewCapacity, pq.Array(&user.Tags))
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to update user tags: %w", err)
+	}
+
+	return &user, nil
+}
+
+// MoveUserTeam переводит пользователя в команду teamName и поступает с его открытыми
+// ревью согласно mode. Отчёт перечисляет каждый затронутый PR.
+func (r *UserRepository) MoveUserTeam(ctx context.Context, userID, teamName, mode string, pick models.ReviewerPickFunc) (*models.User, []models.ReviewHandoff, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to begin tx: %w", err)
+	}
+	defer tx.Rollback()
 
-	prsToReassign, err := r.findUserOpenPRs(ctx, tx, userID)
+	var oldTeam sql.NullString
+	err = tx.QueryRowContext(ctx, "SELECT team_name FROM users WHERE id = $1 FOR UPDATE", userID).Scan(&oldTeam)
+	if err == sql.ErrNoRows {
+		return nil, nil, ErrNotFound
+	} else if err != nil {
+		return nil, nil, fmt.Errorf("failed to select user: %w", err)
+	}
+
+	var exists bool
+	err = tx.QueryRowContext(ctx, "SELECT EXISTS(SELECT 1 FROM teams WHERE team_name = $1)", teamName).Scan(&exists)
 	if err != nil {
-		return err
+		return nil, nil, fmt.Errorf("failed to check team exists: %w", err)
+	}
+	if !exists {
+		return nil, nil, ErrNotFound
+	}
+
+	var user models.User
+	err = tx.QueryRowContext(ctx, `
+        UPDATE users
+        SET team_name = $1, updated_at = CURRENT_TIMESTAMP
+        WHERE id = $2
+        RETURNING id, username, COALESCE(team_name, ''), is_active, review_capacity, tags`,
+		teamName, userID).Scan(&user.UserID, &user.Username, &user.TeamName, &user.IsActive, &user.ReviewCapacity, pq.Array(&user.Tags))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to move user: %w", err)
+	}
+
+	report := []models.ReviewHandoff{}
+	if oldTeam.String != teamName {
+		report, err = handOffMovedUserReviews(ctx, tx, userID, oldTeam.String, mode, pick)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, nil, fmt.Errorf("failed to tx commit: %w", err)
+	}
+
+	return &user, report, nil
+}
+
+// handOffMovedUserReviews поступает с открытыми ревью пользователя, покинувшего команду
+// oldTeam, согласно mode. При reassign замена ищется только в прежней команде,
+// резервные команды не используются.
+func handOffMovedUserReviews(ctx context.Context, tx *sql.Tx, userID, oldTeam, mode string, pick models.ReviewerPickFunc) ([]models.ReviewHandoff, error) {
+	switch mode {
+	case models.HandoffReassign:
+		report, err := handOffUserReviews(ctx, tx, userID, oldTeam, models.ReasonTeamChanged, pick, false)
+		if err != nil {
+			return nil, fmt.Errorf("failed to reassign user reviews: %w", err)
+		}
+		return report, nil
+	case models.HandoffDrop:
+		report, err := dropUserReviews(ctx, tx, userID, models.ReasonTeamChanged)
+		if err != nil {
+			return nil, fmt.Errorf("failed to drop user reviews: %w", err)
+		}
+		return report, nil
 	}
 
-	if len(prsToReassign) == 0 {
-		return nil
+	prs, err := findUserOpenPRs(ctx, tx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select user reviews: %w", err)
+	}
+	report := []models.ReviewHandoff{}
+	for _, pr := range prs {
+		report = append(report, models.ReviewHandoff{PullRequestID: pr.PRID, Action: models.ReviewKept})
+	}
+	return report, nil
+}
+
+// reassignUserReviews заменяет пользователя во всех его открытых ревью участником
+// команды teamName или её резервных команд. Если замены нет, ревьювер снимается.
+func reassignUserReviews(ctx context.Context, tx *sql.Tx, userID, teamName, reason string, pick models.ReviewerPickFunc) ([]models.ReviewHandoff, error) {
+	return handOffUserReviews(ctx, tx, userID, teamName, reason, pick, true)
+}
+
+// handOffUserReviews — reassignUserReviews, где withFallback определяет, можно ли брать
+// замену из резервных команд teamName.
+func handOffUserReviews(ctx context.Context, tx *sql.Tx, userID, teamName, reason string, pick models.ReviewerPickFunc, withFallback bool) ([]models.ReviewHandoff, error) {
+	prsToReassign, err := findUserOpenPRs(ctx, tx, userID)
+	if err != nil {
+		return nil, err
 	}
 
+	report := []models.ReviewHandoff{}
 	for _, pr := range prsToReassign {
-		newReviewer, err := r.findReplacementReviewer(ctx, tx, userID, pr.AuthorID, teamName, pr.PRID)
+		newReviewer, err := findReplacementReviewer(ctx, tx, userID, pr.AuthorID, teamName, pr.PRID, pick, withFallback)
 		if err != nil {
 			if err == sql.ErrNoRows {
-				if err := r.removeReviewer(ctx, tx, pr.PRID, userID); err != nil {
-					return err
+				if err := removeReviewer(ctx, tx, pr.PRID, userID, reason); err != nil {
+					return nil, err
 				}
+				report = append(report, models.ReviewHandoff{PullRequestID: pr.PRID, Action: models.ReviewDropped})
 				continue
 			}
-			return err
+			return nil, err
+		}
+		if err := replaceReviewer(ctx, tx, pr.PRID, userID, newReviewer, reason); err != nil {
+			return nil, err
 		}
-		if err := r.replaceReviewer(ctx, tx, pr.PRID, userID, newReviewer); err != nil {
-			return err
+		report = append(report, models.ReviewHandoff{PullRequestID: pr.PRID, Action: models.ReviewReassigned, ReplacedBy: newReviewer})
+	}
+
+	return report, nil
+}
+
+// dropUserReviews снимает пользователя со всех его открытых ревью без замены.
+func dropUserReviews(ctx context.Context, tx *sql.Tx, userID, reason string) ([]models.ReviewHandoff, error) {
+	prs, err := findUserOpenPRs(ctx, tx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := []models.ReviewHandoff{}
+	for _, pr := range prs {
+		if err := removeReviewer(ctx, tx, pr.PRID, userID, reason); err != nil {
+			return nil, err
 		}
+		report = append(report, models.ReviewHandoff{PullRequestID: pr.PRID, Action: models.ReviewDropped})
 	}
 
-	return nil
+	return report, nil
 }
 
-func (r *UserRepository) findUserOpenPRs(ctx context.Context, tx *sql.Tx, userID string) ([]models.PRReview, error) {
+func findUserOpenPRs(ctx context.Context, tx *sql.Tx, userID string) ([]models.PRReview, error) {
 	query := `
         SELECT 
             pr.pull_request_id,
@@ -159,45 +505,47 @@ func (r *UserRepository) findUserOpenPRs(ctx context.Context, tx *sql.Tx, userID
 	return prs, rows.Err()
 }
 
-func (r *UserRepository) findReplacementReviewer(ctx context.Context, tx *sql.Tx, oldReviewerID, authorID, teamName, prID string) (string, error) {
-	var newReviewer string
-
-	err := tx.QueryRowContext(ctx, `
-        SELECT u.id 
-        FROM users u
-        WHERE u.team_name = $1 
-        AND u.is_active = true 
-        AND u.id != $2  -- исключаем старого ревьювера
-        AND u.id != $3  -- исключаем автора PR
-        AND u.id NOT IN (
-            SELECT reviewer_id 
-            FROM pr_reviewers 
-            WHERE pull_request_id = $4
-        )
-        ORDER BY RANDOM()
-        LIMIT 1
-    `, teamName, oldReviewerID, authorID, prID).Scan(&newReviewer)
+func findReplacementReviewer(ctx context.Context, tx *sql.Tx, oldReviewerID, authorID, teamName, prID string, pick models.ReviewerPickFunc, withFallback bool) (string, error) {
+	// исключаем старого ревьювера и автора PR
+	exclude := []string{oldReviewerID, authorID}
 
+	var picked []string
+	var err error
+	if withFallback {
+		picked, _, err = pickReviewersWithFallback(ctx, tx, pick, teamName, prID, exclude, 1)
+	} else {
+		picked, err = pickReviewers(ctx, tx, pick, teamName, prID, exclude, 1)
+	}
 	if err != nil {
 		return "", err
 	}
 
-	return newReviewer, nil
+	if len(picked) == 0 {
+		return "", sql.ErrNoRows
+	}
+
+	return picked[0], nil
 }
 
-func (r *UserRepository) replaceReviewer(ctx context.Context, tx *sql.Tx, prID, oldReviewerID, newReviewerID string) error {
+func replaceReviewer(ctx context.Context, tx *sql.Tx, prID, oldReviewerID, newReviewerID, reason string) error {
 	_, err := tx.ExecContext(ctx, `
         UPDATE pr_reviewers 
-        SET reviewer_id = $1 
+        SET reviewer_id = $1, assigned_at = CURRENT_TIMESTAMP
         WHERE pull_request_id = $2 AND reviewer_id = $3
     `, newReviewerID, prID, oldReviewerID)
-	return err
+	if err != nil {
+		return err
+	}
+	return recordAssignment(ctx, tx, prID, models.AssignmentReassigned, oldReviewerID, newReviewerID, reason)
 }
 
-func (r *UserRepository) removeReviewer(ctx context.Context, tx *sql.Tx, prID, reviewerID string) error {
+func removeReviewer(ctx context.Context, tx *sql.Tx, prID, reviewerID, reason string) error {
 	_, err := tx.ExecContext(ctx, `
         DELETE FROM pr_reviewers 
         WHERE pull_request_id = $1 AND reviewer_id = $2
     `, prID, reviewerID)
-	return err
+	if err != nil {
+		return err
+	}
+	return recordAssignment(ctx, tx, prID, models.AssignmentRemoved, reviewerID, "", reason)
 }
@@ -0,0 +1,257 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/RomanKovalev007/pull_request_service/include/models"
+	"github.com/lib/pq"
+)
+
+type WebhookRepository struct {
+	db *sql.DB
+}
+
+func NewWebhookRepository(db *sql.DB) *WebhookRepository {
+	return &WebhookRepository{db: db}
+}
+
+func (r *WebhookRepository) CreateWebhook(ctx context.Context, hook models.Webhook) (*models.Webhook, error) {
+	err := r.db.QueryRowContext(ctx, `
+        INSERT INTO webhooks (url, secret, events)
+        VALUES ($1, $2, $3)
+        RETURNING id, is_active, created_at`,
+		hook.URL, hook.Secret, pq.Array(hook.Events)).Scan(&hook.ID, &hook.IsActive, &hook.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create webhook: %w", err)
+	}
+
+	return &hook, nil
+}
+
+func (r *WebhookRepository) ListWebhooks(ctx context.Context) ([]models.Webhook, error) {
+	rows, err := r.db.QueryContext(ctx, `
+        SELECT id, url, events, is_active, created_at
+        FROM webhooks
+        ORDER BY id`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select webhooks: %w", err)
+	}
+	defer rows.Close()
+
+	hooks := []models.Webhook{}
+	for rows.Next() {
+		var hook models.Webhook
+		if err := rows.Scan(&hook.ID, &hook.URL, pq.Array(&hook.Events), &hook.IsActive, &hook.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan webhook: %w", err)
+		}
+		if hook.Events == nil {
+			hook.Events = []string{}
+		}
+		hooks = append(hooks, hook)
+	}
+
+	return hooks, rows.Err()
+}
+
+// DeleteWebhook удаляет подписку вместе с её доставками.
+func (r *WebhookRepository) DeleteWebhook(ctx context.Context, id int64) error {
+	res, err := r.db.ExecContext(ctx, "DELETE FROM webhooks WHERE id = $1", id)
+	if err != nil {
+		return fmt.Errorf("failed to delete webhook: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to delete webhook: %w", err)
+	}
+	if affected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// FanOutEvents создаёт доставки для новых событий outbox по активным подпискам
+// и отмечает события разосланными. Возвращает число обработанных событий.
+func (r *WebhookRepository) FanOutEvents(ctx context.Context, limit int) (int, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, `
+        SELECT id, event_type
+        FROM outbox_events
+        WHERE dispatched_at IS NULL
+        ORDER BY id
+        LIMIT $1
+        FOR UPDATE SKIP LOCKED`, limit)
+	if err != nil {
+		return 0, fmt.Errorf("failed to select outbox events: %w", err)
+	}
+
+	type pending struct {
+		id        int64
+		eventType string
+	}
+
+	var events []pending
+	for rows.Next() {
+		var e pending
+		if err := rows.Scan(&e.id, &e.eventType); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("failed to scan outbox event: %w", err)
+		}
+		events = append(events, e)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("rows error: %w", err)
+	}
+
+	for _, e := range events {
+		_, err := tx.ExecContext(ctx, `
+            INSERT INTO webhook_deliveries (webhook_id, event_id)
+            SELECT id, $1::bigint FROM webhooks
+            WHERE is_active = true
+            AND (cardinality(events) = 0 OR $2::text = ANY(events))
+            ON CONFLICT (webhook_id, event_id) DO NOTHING`, e.id, e.eventType)
+		if err != nil {
+			return 0, fmt.Errorf("failed to create deliveries: %w", err)
+		}
+
+		_, err = tx.ExecContext(ctx, `
+            UPDATE outbox_events
+            SET dispatched_at = CURRENT_TIMESTAMP
+            WHERE id = $1`, e.id)
+		if err != nil {
+			return 0, fmt.Errorf("failed to mark event dispatched: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to tx commit: %w", err)
+	}
+
+	return len(events), nil
+}
+
+// ClaimDueDeliveries выбирает доставки, время попытки которых наступило, и откладывает
+// их на lease, чтобы другой экземпляр сервиса не отправил их одновременно.
+func (r *WebhookRepository) ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]models.WebhookDelivery, error) {
+	rows, err := r.db.QueryContext(ctx, `
+        WITH due AS (
+            SELECT id FROM webhook_deliveries
+            WHERE status = 'PENDING' AND next_attempt_at <= CURRENT_TIMESTAMP
+            ORDER BY next_attempt_at, id
+            LIMIT $1
+            FOR UPDATE SKIP LOCKED
+        )
+        UPDATE webhook_deliveries d
+        SET next_attempt_at = CURRENT_TIMESTAMP + $2::bigint * INTERVAL '1 millisecond'
+        FROM due, webhooks w, outbox_events e
+        WHERE d.id = due.id AND w.id = d.webhook_id AND e.id = d.event_id
+        RETURNING d.id, d.webhook_id, w.url, w.secret, e.id, e.event_type, e.payload, e.created_at,
+            d.status, d.attempts, d.next_attempt_at, d.last_error, d.last_status_code`,
+		limit, lease.Milliseconds())
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	var deliveries []models.WebhookDelivery
+	for rows.Next() {
+		var d models.WebhookDelivery
+		if err := rows.Scan(&d.ID, &d.WebhookID, &d.URL, &d.Secret, &d.Event.ID, &d.Event.Type, &d.Event.Payload, &d.Event.CreatedAt,
+			&d.Status, &d.Attempts, &d.NextAttemptAt, &d.LastError, &d.LastStatusCode); err != nil {
+			return nil, fmt.Errorf("failed to scan delivery: %w", err)
+		}
+		deliveries = append(deliveries, d)
+	}
+
+	return deliveries, rows.Err()
+}
+
+func (r *WebhookRepository) MarkDelivered(ctx context.Context, id int64, statusCode int) error {
+	_, err := r.db.ExecContext(ctx, `
+        UPDATE webhook_deliveries
+        SET status = 'DELIVERED', attempts = attempts + 1, last_status_code = $2,
+            last_error = '', delivered_at = CURRENT_TIMESTAMP
+        WHERE id = $1`, id, statusCode)
+	if err != nil {
+		return fmt.Errorf("failed to mark delivery delivered: %w", err)
+	}
+	return nil
+}
+
+// MarkFailed фиксирует неудачную попытку. Если nextAttemptAt не задан, доставка
+// переходит в DEAD и больше не повторяется.
+func (r *WebhookRepository) MarkFailed(ctx context.Context, id int64, statusCode *int, lastError string, nextAttemptAt *time.Time) error {
+	status := models.DeliveryPending
+	if nextAttemptAt == nil {
+		status = models.DeliveryDead
+	}
+
+	_, err := r.db.ExecContext(ctx, `
+        UPDATE webhook_deliveries
+        SET status = $2, attempts = attempts + 1, last_status_code = $3, last_error = $4,
+            next_attempt_at = COALESCE($5, next_attempt_at)
+        WHERE id = $1`, id, status, statusCode, lastError, nextAttemptAt)
+	if err != nil {
+		return fmt.Errorf("failed to mark delivery failed: %w", err)
+	}
+	return nil
+}
+
+// ListDeadDeliveries возвращает доставки, исчерпавшие попытки. webhookID = 0 — по всем подпискам.
+func (r *WebhookRepository) ListDeadDeliveries(ctx context.Context, webhookID int64) ([]models.WebhookDelivery, error) {
+	rows, err := r.db.QueryContext(ctx, `
+        SELECT d.id, d.webhook_id, w.url, e.id, e.event_type, e.payload, e.created_at,
+            d.status, d.attempts, d.next_attempt_at, d.last_error, d.last_status_code
+        FROM webhook_deliveries d
+        JOIN webhooks w ON w.id = d.webhook_id
+        JOIN outbox_events e ON e.id = d.event_id
+        WHERE d.status = 'DEAD' AND ($1::bigint = 0 OR d.webhook_id = $1)
+        ORDER BY d.id`, webhookID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select dead deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	deliveries := []models.WebhookDelivery{}
+	for rows.Next() {
+		var d models.WebhookDelivery
+		if err := rows.Scan(&d.ID, &d.WebhookID, &d.URL, &d.Event.ID, &d.Event.Type, &d.Event.Payload, &d.Event.CreatedAt,
+			&d.Status, &d.Attempts, &d.NextAttemptAt, &d.LastError, &d.LastStatusCode); err != nil {
+			return nil, fmt.Errorf("failed to scan delivery: %w", err)
+		}
+		deliveries = append(deliveries, d)
+	}
+
+	return deliveries, rows.Err()
+}
+
+// RetryDelivery возвращает доставку из DEAD в очередь с обнулённым счётчиком попыток.
+func (r *WebhookRepository) RetryDelivery(ctx context.Context, id int64) error {
+	res, err := r.db.ExecContext(ctx, `
+        UPDATE webhook_deliveries
+        SET status = 'PENDING', attempts = 0, next_attempt_at = CURRENT_TIMESTAMP
+        WHERE id = $1 AND status = 'DEAD'`, id)
+	if err != nil {
+		return fmt.Errorf("failed to retry delivery: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to retry delivery: %w", err)
+	}
+	if affected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/RomanKovalev007/pull_request_service/include/models"
+	"github.com/RomanKovalev007/pull_request_service/include/repository"
+	transport "github.com/RomanKovalev007/pull_request_service/include/transport/models"
+)
+
+type adminRepository interface {
+	ImportRoster(ctx context.Context, roster []models.RosterEntry, dryRun bool, pick models.ReviewerPickFunc) (*models.ImportPlan, error)
+	ExportSnapshot(ctx context.Context) (*models.Snapshot, error)
+	RestoreSnapshot(ctx context.Context, snap models.Snapshot) error
+}
+
+type AdminService struct {
+	adminRepo adminRepository
+	selector  *StrategySelector
+}
+
+func NewAdminService(adminRepo adminRepository, selector *StrategySelector) *AdminService {
+	return &AdminService{adminRepo: adminRepo, selector: selector}
+}
+
+func (s *AdminService) ImportRoster(ctx context.Context, req transport.ImportRequest) (*transport.ImportResponse, error) {
+	if err := s.validateImportRoster(req.Roster); err != nil {
+		return nil, err
+	}
+
+	plan, err := s.adminRepo.ImportRoster(ctx, req.Roster, req.DryRun, s.selector.Select)
+	if err != nil {
+		return nil, &ServiceError{Code: err.Error(), Message: "failed to import roster"}
+	}
+
+	return &transport.ImportResponse{Plan: *plan}, nil
+}
+
+func (s *AdminService) ExportSnapshot(ctx context.Context) (*models.Snapshot, error) {
+	snap, err := s.adminRepo.ExportSnapshot(ctx)
+	if err != nil {
+		return nil, &ServiceError{Code: err.Error(), Message: "failed to export data"}
+	}
+
+	return snap, nil
+}
+
+func (s *AdminService) RestoreSnapshot(ctx context.Context, snap models.Snapshot) (*transport.RestoreResponse, error) {
+	if err := s.validateRestoreSnapshot(snap); err != nil {
+		return nil, err
+	}
+
+	if err := s.adminRepo.RestoreSnapshot(ctx, snap); err != nil {
+		if errors.Is(err, repository.ErrNotEmpty) {
+			return nil, &ServiceError{Code: err.Error(), Message: "database is not empty"}
+		}
+		return nil, &ServiceError{Code: err.Error(), Message: "failed to restore data"}
+	}
+
+	return &transport.RestoreResponse{
+		Teams:        len(snap.Teams),
+		Users:        len(snap.Users),
+		PullRequests: len(snap.PullRequests),
+	}, nil
+}
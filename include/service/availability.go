@@ -0,0 +1,91 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/RomanKovalev007/pull_request_service/include/models"
+	transport "github.com/RomanKovalev007/pull_request_service/include/transport/models"
+)
+
+func (s *UserService) AddAvailability(ctx context.Context, req models.Availability) (*transport.AvailabilityResponse, error) {
+	if err := s.validateAvailability(req); err != nil {
+		return nil, err
+	}
+
+	availability, err := s.userRepo.CreateAvailability(ctx, req)
+	if err != nil {
+		return nil, &ServiceError{Code: err.Error(), Message: "failed to add availability"}
+	}
+
+	return &transport.AvailabilityResponse{Availability: *availability}, nil
+}
+
+func (s *UserService) GetUserAvailability(ctx context.Context, userID string) (*transport.UserAvailabilityResponse, error) {
+	if err := s.validateUserID(userID); err != nil {
+		return nil, err
+	}
+
+	periods, err := s.userRepo.GetUserAvailability(ctx, userID)
+	if err != nil {
+		return nil, &ServiceError{Code: err.Error(), Message: "failed to get user availability"}
+	}
+
+	return &transport.UserAvailabilityResponse{UserID: userID, Availability: periods}, nil
+}
+
+func (s *UserService) UpdateAvailability(ctx context.Context, req models.Availability) (*transport.AvailabilityResponse, error) {
+	if req.ID <= 0 {
+		return nil, &ServiceError{Code: ErrInvalidInput.Error(), Message: "id is required"}
+	}
+	if err := s.validateAvailabilityPeriod(req); err != nil {
+		return nil, err
+	}
+
+	availability, err := s.userRepo.UpdateAvailability(ctx, req)
+	if err != nil {
+		return nil, &ServiceError{Code: err.Error(), Message: "failed to update availability"}
+	}
+
+	return &transport.AvailabilityResponse{Availability: *availability}, nil
+}
+
+func (s *UserService) DeleteAvailability(ctx context.Context, req transport.AvailabilityDeleteRequest) error {
+	if req.ID <= 0 {
+		return &ServiceError{Code: ErrInvalidInput.Error(), Message: "id is required"}
+	}
+
+	if err := s.userRepo.DeleteAvailability(ctx, req.ID); err != nil {
+		return &ServiceError{Code: err.Error(), Message: "failed to delete availability"}
+	}
+
+	return nil
+}
+
+// RunAvailabilityWorker периодически переназначает ревью пользователей, у которых начался период отсутствия.
+// Возвращает управление после отмены ctx, неположительный интервал отключает обработку.
+func (s *UserService) RunAvailabilityWorker(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			count, err := s.userRepo.ReassignUnavailableReviews(ctx, s.selector.Select)
+			if err != nil {
+				log.Printf("failed to process availability windows: %v", err)
+				continue
+			}
+			if count > 0 {
+				log.Printf("Reassigned reviews for %d started availability windows", count)
+			}
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package service
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/RomanKovalev007/pull_request_service/include/models"
+)
+
+const (
+	reviewerSyncBatchSize = 50
+	// reviewerSyncConcurrency — сколько PR выгружается одновременно.
+	reviewerSyncConcurrency = 10
+	// codeHostCallsPerSync — сколько запросов к code host может занять одна попытка:
+	// чтение запрошенных ревьюверов, снятие и запрос.
+	codeHostCallsPerSync = 3
+	// reviewerSyncLease — на сколько откладывается повтор выгрузки, пока идёт попытка.
+	reviewerSyncLease = time.Minute
+)
+
+// CodeHostClient выгружает назначенных ревьюверов в code host, где размещён PR.
+type CodeHostClient interface {
+	// Supports сообщает, относится ли ID PR к этому code host.
+	Supports(prID string) bool
+	// SetRequestedReviewers запрашивает у PR ревьюверов reviewers (users.id) и снимает
+	// тех из previous — выгруженных раньше, — кого больше нет в reviewers. Ревьюверы,
+	// запрошенные в code host вручную, не снимаются. Повторный вызов ничего не меняет.
+	SetRequestedReviewers(ctx context.Context, prID string, reviewers, previous []string) error
+}
+
+type reviewerSyncRepository interface {
+	QueueReviewerSync(ctx context.Context, prID string) (*models.ReviewerSync, error)
+	ClaimDueReviewerSyncs(ctx context.Context, limit int, lease time.Duration) ([]models.DueReviewerSync, error)
+	MarkReviewerSynced(ctx context.Context, prID string, revision int64, pushed []string) (*models.ReviewerSync, error)
+	MarkReviewerSyncFailed(ctx context.Context, prID string, revision int64, lastError string, nextAttemptAt *time.Time) (*models.ReviewerSync, error)
+	GetPullRequest(ctx context.Context, prID string) (*models.PullRequest, error)
+}
+
+type ReviewerSyncService struct {
+	syncRepo reviewerSyncRepository
+	client   CodeHostClient
+	cfg      ReviewerConfig
+}
+
+func NewReviewerSyncService(syncRepo reviewerSyncRepository, client CodeHostClient, cfg ReviewerConfig) *ReviewerSyncService {
+	return &ReviewerSyncService{syncRepo: syncRepo, client: client, cfg: cfg}
+}
+
+// Push ставит выгрузку ревьюверов PR в очередь и записывает её состояние в pr.ReviewerSync.
+// Code host вызывается фоновым воркером, чтобы запрос клиента и вебхук не ждали его ответа.
+// PR, не относящиеся к code host, пропускаются. Дальнейшие изменения состава ревьюверов
+// ставят выгрузку в очередь заново в репозитории.
+func (s *ReviewerSyncService) Push(ctx context.Context, pr *models.PullRequest) {
+	if s == nil || !s.client.Supports(pr.PullRequestID) {
+		return
+	}
+
+	sync, err := s.syncRepo.QueueReviewerSync(ctx, pr.PullRequestID)
+	if err != nil {
+		log.Printf("failed to queue reviewer sync for %s: %v", pr.PullRequestID, err)
+		return
+	}
+	pr.ReviewerSync = sync
+}
+
+// RunWorker периодически выполняет поставленные в очередь и неудавшиеся выгрузки.
+// Возвращает управление после отмены ctx, неположительный интервал отключает выгрузку.
+func (s *ReviewerSyncService) RunWorker(ctx context.Context, interval time.Duration) {
+	if s == nil || interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.RetryDue(ctx); err != nil {
+				log.Printf("failed to retry reviewer syncs: %v", err)
+			}
+		}
+	}
+}
+
+// RetryDue выполняет один проход выгрузки. Выгружается текущий состав ревьюверов,
+// а не тот, что был при постановке в очередь или неудачной попытке. PR выгружаются
+// параллельно, а их число ограничено так, чтобы проход укладывался в reviewerSyncLease.
+func (s *ReviewerSyncService) RetryDue(ctx context.Context) error {
+	limit := leaseBatchSize(reviewerSyncLease, codeHostCallsPerSync*s.cfg.CodeHostTimeout, reviewerSyncConcurrency, reviewerSyncBatchSize)
+	due, err := s.syncRepo.ClaimDueReviewerSyncs(ctx, limit, reviewerSyncLease)
+	if err != nil {
+		return err
+	}
+
+	queue := make(chan models.DueReviewerSync)
+
+	var wg sync.WaitGroup
+	for range min(reviewerSyncConcurrency, len(due)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for d := range queue {
+				s.syncDue(ctx, d)
+			}
+		}()
+	}
+
+	for _, d := range due {
+		queue <- d
+	}
+	close(queue)
+	wg.Wait()
+
+	return nil
+}
+
+// syncDue выгружает один PR. Ошибка записывается в лог и не прерывает проход:
+// выгрузка будет выбрана снова, когда истечёт reviewerSyncLease.
+func (s *ReviewerSyncService) syncDue(ctx context.Context, d models.DueReviewerSync) {
+	pr, err := s.syncRepo.GetPullRequest(ctx, d.PullRequestID)
+	if err != nil {
+		log.Printf("failed to load pull request %s for reviewer sync: %v", d.PullRequestID, err)
+		return
+	}
+	if _, err := s.attempt(ctx, d, pr.AssignedReviewers); err != nil {
+		log.Printf("failed to record reviewer sync for %s: %v", d.PullRequestID, err)
+	}
+}
+
+// attempt выполняет одну попытку выгрузки и записывает её результат.
+func (s *ReviewerSyncService) attempt(ctx context.Context, d models.DueReviewerSync, reviewers []string) (*models.ReviewerSync, error) {
+	err := s.client.SetRequestedReviewers(ctx, d.PullRequestID, reviewers, d.PushedReviewers)
+	if err == nil {
+		return s.syncRepo.MarkReviewerSynced(ctx, d.PullRequestID, d.Revision, reviewers)
+	}
+
+	var next *time.Time
+	if attempt := d.Attempts + 1; attempt < s.cfg.CodeHostMaxAttempts {
+		at := time.Now().Add(backoffDelay(s.cfg.CodeHostBackoffBase, s.cfg.CodeHostBackoffMax, attempt))
+		next = &at
+	}
+
+	return s.syncRepo.MarkReviewerSyncFailed(ctx, d.PullRequestID, d.Revision, err.Error(), next)
+}
@@ -0,0 +1,229 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/RomanKovalev007/pull_request_service/include/models"
+	"github.com/RomanKovalev007/pull_request_service/include/repository"
+	transport "github.com/RomanKovalev007/pull_request_service/include/transport/models"
+)
+
+// codeOwnersLocations — где ищется CODEOWNERS в рабочей копии, в порядке GitHub.
+var codeOwnersLocations = []string{".github/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS"}
+
+// CodeOwners — разобранный файл CODEOWNERS.
+type CodeOwners struct {
+	rules []codeOwnersRule
+}
+
+type codeOwnersRule struct {
+	pattern *regexp.Regexp
+	owners  []string
+}
+
+// ParseCodeOwners разбирает CODEOWNERS в синтаксисе GitHub: шаблон пути и владельцы
+// @user, @org/team или email. Строка без владельцев снимает владельцев с пути.
+func ParseCodeOwners(content string) (*CodeOwners, error) {
+	var co CodeOwners
+
+	for i, line := range strings.Split(content, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+
+		pattern, err := codeOwnersPattern(strings.TrimPrefix(fields[0], `\`))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+
+		rule := codeOwnersRule{pattern: pattern}
+		for _, owner := range fields[1:] {
+			if strings.HasPrefix(owner, "#") {
+				break
+			}
+			if !strings.Contains(owner, "@") || owner == "@" {
+				return nil, fmt.Errorf("line %d: invalid owner %q", i+1, owner)
+			}
+			rule.owners = append(rule.owners, owner)
+		}
+		co.rules = append(co.rules, rule)
+	}
+
+	return &co, nil
+}
+
+// Owners возвращает владельцев пути по последнему подходящему правилу.
+func (co *CodeOwners) Owners(path string) []string {
+	path = strings.TrimPrefix(strings.TrimPrefix(path, "./"), "/")
+
+	for i := len(co.rules) - 1; i >= 0; i-- {
+		if co.rules[i].pattern.MatchString(path) {
+			return co.rules[i].owners
+		}
+	}
+	return nil
+}
+
+// codeOwnersPattern переводит шаблон gitignore в регулярное выражение. Шаблон со слэшем
+// в начале или середине привязан к корню, без слэша — совпадает на любой глубине.
+// Шаблон, совпавший с каталогом, покрывает всё его содержимое, кроме шаблонов вида "dir/*".
+func codeOwnersPattern(pattern string) (*regexp.Regexp, error) {
+	if strings.HasPrefix(pattern, "!") || strings.ContainsAny(pattern, "[]") {
+		return nil, fmt.Errorf("unsupported pattern %q", pattern)
+	}
+
+	anchored := strings.Contains(strings.TrimSuffix(pattern, "/"), "/")
+	dirOnly := strings.HasSuffix(pattern, "/")
+	pattern = strings.Trim(pattern, "/")
+	if pattern == "" {
+		return nil, fmt.Errorf("empty pattern")
+	}
+
+	var b strings.Builder
+	if anchored {
+		b.WriteString("^")
+	} else {
+		b.WriteString("^(?:.*/)?")
+	}
+
+	for i := 0; i < len(pattern); i++ {
+		switch {
+		case strings.HasPrefix(pattern[i:], "**/"):
+			b.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(pattern[i:], "**"):
+			b.WriteString(".*")
+			i++
+		case pattern[i] == '*':
+			b.WriteString("[^/]*")
+		case pattern[i] == '?':
+			b.WriteString("[^/]")
+		default:
+			b.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		}
+	}
+
+	switch {
+	case dirOnly:
+		b.WriteString("/.*$")
+	case strings.HasSuffix(pattern, "/*") && !strings.HasSuffix(pattern, "**/*"):
+		b.WriteString("$")
+	default:
+		b.WriteString("(?:/.*)?$")
+	}
+
+	return regexp.Compile(b.String())
+}
+
+// readCheckoutCodeOwners читает CODEOWNERS из рабочей копии. Если файла нет, возвращает "".
+func readCheckoutCodeOwners(checkout string) (string, error) {
+	for _, location := range codeOwnersLocations {
+		content, err := os.ReadFile(filepath.Join(checkout, location))
+		if err == nil {
+			return string(content), nil
+		}
+		if !os.IsNotExist(err) {
+			return "", err
+		}
+	}
+	return "", nil
+}
+
+// codeOwnersPreference отбирает кандидатов, владеющих изменёнными файлами по CODEOWNERS
+// команды автора: загруженному через API или из рабочей копии CODEOWNERS_TEAM_CHECKOUTS.
+// Если владельцев нет, возвращает nil.
+func (s *PrService) codeOwnersPreference(ctx context.Context, authorID string, changedFiles []string) (*models.ReviewerPreference, error) {
+	if len(changedFiles) == 0 {
+		return nil, nil
+	}
+
+	team, err := s.prRepo.GetAuthorCodeOwners(ctx, authorID)
+	if errors.Is(err, repository.ErrNotFound) {
+		// автор без команды отклоняется при создании PR
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	content := team.Content
+	if checkout, ok := s.cfg.CodeOwnersCheckouts[team.TeamName]; ok && content == "" {
+		if content, err = readCheckoutCodeOwners(checkout); err != nil {
+			log.Printf("failed to read CODEOWNERS of team %s: %v", team.TeamName, err)
+			return nil, nil
+		}
+	}
+	if content == "" {
+		return nil, nil
+	}
+
+	co, err := ParseCodeOwners(content)
+	if err != nil {
+		log.Printf("invalid CODEOWNERS of team %s: %v", team.TeamName, err)
+		return nil, nil
+	}
+
+	users := make(map[string]bool)
+	teams := make(map[string]bool)
+	for _, file := range changedFiles {
+		for _, owner := range co.Owners(file) {
+			name, ok := strings.CutPrefix(owner, "@")
+			if !ok {
+				// владельцы-email не сопоставляются с пользователями
+				continue
+			}
+			if _, teamName, ok := strings.Cut(name, "/"); ok {
+				teams[teamName] = true
+				continue
+			}
+			if userID, ok := s.cfg.GitHubLogins[name]; ok {
+				name = userID
+			}
+			users[name] = true
+		}
+	}
+	if len(users) == 0 && len(teams) == 0 {
+		return nil, nil
+	}
+
+	return &models.ReviewerPreference{
+		Reason: models.SelectedByCodeOwner,
+		Match: func(c models.ReviewerCandidate) bool {
+			return users[c.UserID] || teams[c.TeamName]
+		},
+	}, nil
+}
+
+func (s *TeamService) GetTeamCodeOwners(ctx context.Context, teamName string) (*models.TeamCodeOwners, error) {
+	if err := s.validateGetTeam(teamName); err != nil {
+		return nil, err
+	}
+
+	owners, err := s.teamRepo.GetTeamCodeOwners(ctx, teamName)
+	if err != nil {
+		return nil, &ServiceError{Code: err.Error(), Message: "failed to get team codeowners"}
+	}
+	return owners, nil
+}
+
+// SetTeamCodeOwners загружает CODEOWNERS команды. Пустой content удаляет файл,
+// после чего используется рабочая копия из CODEOWNERS_TEAM_CHECKOUTS, если она задана.
+func (s *TeamService) SetTeamCodeOwners(ctx context.Context, req models.TeamCodeOwners) (*transport.TeamCodeOwnersResponse, error) {
+	if err := s.validateSetTeamCodeOwners(req); err != nil {
+		return nil, err
+	}
+
+	owners, err := s.teamRepo.SetTeamCodeOwners(ctx, req)
+	if err != nil {
+		return nil, &ServiceError{Code: err.Error(), Message: "failed to set team codeowners"}
+	}
+
+	return &transport.TeamCodeOwnersResponse{CodeOwners: *owners}, nil
+}
@@ -0,0 +1,33 @@
+package service
+
+import "time"
+
+type ReviewerConfig struct {
+	ReviewerStrategy       string            `env:"REVIEWER_STRATEGY" env-default:"random"`
+	TeamReviewerStrategies map[string]string `env:"REVIEWER_TEAM_STRATEGIES"`
+	ReviewerWeights        map[string]int    `env:"REVIEWER_WEIGHTS"`
+	// CodeOwnersCheckouts — рабочие копии репозиториев команд, из которых читается CODEOWNERS.
+	CodeOwnersCheckouts map[string]string `env:"CODEOWNERS_TEAM_CHECKOUTS"`
+
+	AvailabilityCheckInterval time.Duration `env:"AVAILABILITY_CHECK_INTERVAL" env-default:"1m"`
+
+	WebhookDispatchInterval time.Duration `env:"WEBHOOK_DISPATCH_INTERVAL" env-default:"5s"`
+	WebhookTimeout          time.Duration `env:"WEBHOOK_TIMEOUT" env-default:"10s"`
+	WebhookMaxAttempts      int           `env:"WEBHOOK_MAX_ATTEMPTS" env-default:"8"`
+	WebhookBackoffBase      time.Duration `env:"WEBHOOK_BACKOFF_BASE" env-default:"10s"`
+	WebhookBackoffMax       time.Duration `env:"WEBHOOK_BACKOFF_MAX" env-default:"1h"`
+
+	GitHubWebhookSecret string            `env:"GITHUB_WEBHOOK_SECRET"`
+	GitHubLogins        map[string]string `env:"GITHUB_LOGINS"`
+	GitHubToken         string            `env:"GITHUB_TOKEN"`
+	GitHubAPIURL        string            `env:"GITHUB_API_URL" env-default:"https://api.github.com"`
+
+	GitLabWebhookToken string            `env:"GITLAB_WEBHOOK_TOKEN"`
+	GitLabUsers        map[string]string `env:"GITLAB_USERS"`
+
+	CodeHostSyncInterval time.Duration `env:"CODE_HOST_SYNC_INTERVAL" env-default:"5s"`
+	CodeHostTimeout      time.Duration `env:"CODE_HOST_TIMEOUT" env-default:"10s"`
+	CodeHostMaxAttempts  int           `env:"CODE_HOST_MAX_ATTEMPTS" env-default:"6"`
+	CodeHostBackoffBase  time.Duration `env:"CODE_HOST_BACKOFF_BASE" env-default:"30s"`
+	CodeHostBackoffMax   time.Duration `env:"CODE_HOST_BACKOFF_MAX" env-default:"30m"`
+}
@@ -0,0 +1,37 @@
+package service
+
+import (
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/RomanKovalev007/pull_request_service/include/models"
+)
+
+var errInvalidCursor = errors.New("invalid cursor")
+
+// encodeCursor упаковывает позицию страницы в непрозрачную для клиента строку.
+func encodeCursor(c models.PageCursor) string {
+	raw := strconv.FormatInt(c.CreatedAt.UnixNano(), 10) + ":" + c.PullRequestID
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeCursor(s string) (*models.PageCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, errInvalidCursor
+	}
+
+	nanos, id, ok := strings.Cut(string(raw), ":")
+	if !ok || id == "" {
+		return nil, errInvalidCursor
+	}
+	n, err := strconv.ParseInt(nanos, 10, 64)
+	if err != nil {
+		return nil, errInvalidCursor
+	}
+
+	return &models.PageCursor{CreatedAt: time.Unix(0, n).UTC(), PullRequestID: id}, nil
+}
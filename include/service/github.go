@@ -0,0 +1,174 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// GitHubClient выгружает ревьюверов через REST API GitHub. Обслуживает PR с ID вида
+// "<owner>/<repo>#<number>", которые создаёт вебхук GitHub.
+type GitHubClient struct {
+	baseURL string
+	token   string
+	// logins — логины GitHub по users.id, обратное сопоставление GITHUB_LOGINS.
+	logins map[string]string
+	client *http.Client
+}
+
+func NewGitHubClient(cfg ReviewerConfig) *GitHubClient {
+	logins := make(map[string]string, len(cfg.GitHubLogins))
+	for login, userID := range cfg.GitHubLogins {
+		logins[userID] = login
+	}
+
+	return &GitHubClient{
+		baseURL: strings.TrimRight(cfg.GitHubAPIURL, "/"),
+		token:   cfg.GitHubToken,
+		logins:  logins,
+		client:  &http.Client{Timeout: cfg.CodeHostTimeout},
+	}
+}
+
+func (c *GitHubClient) Supports(prID string) bool {
+	_, ok := parseGitHubPR(prID)
+	return ok
+}
+
+// SetRequestedReviewers сверяет запрошенных ревьюверов PR с reviewers: недостающие
+// запрашиваются, а лишние снимаются, только если их запросил сервис (есть в previous).
+func (c *GitHubClient) SetRequestedReviewers(ctx context.Context, prID string, reviewers, previous []string) error {
+	path, ok := parseGitHubPR(prID)
+	if !ok {
+		return fmt.Errorf("not a github pull request: %s", prID)
+	}
+	path += "/requested_reviewers"
+
+	var current struct {
+		Users []struct {
+			Login string `json:"login"`
+		} `json:"users"`
+	}
+	if err := c.do(ctx, http.MethodGet, path, nil, &current); err != nil {
+		return err
+	}
+
+	requested := make(map[string]bool, len(current.Users))
+	for _, u := range current.Users {
+		requested[strings.ToLower(u.Login)] = true
+	}
+
+	wanted := make(map[string]bool, len(reviewers))
+	var add []string
+	for _, userID := range reviewers {
+		login := c.login(userID)
+		wanted[strings.ToLower(login)] = true
+		if !requested[strings.ToLower(login)] {
+			add = append(add, login)
+		}
+	}
+
+	pushed := make(map[string]bool, len(previous))
+	for _, userID := range previous {
+		pushed[strings.ToLower(c.login(userID))] = true
+	}
+
+	var remove []string
+	for _, u := range current.Users {
+		login := strings.ToLower(u.Login)
+		if pushed[login] && !wanted[login] {
+			remove = append(remove, u.Login)
+		}
+	}
+
+	if len(remove) > 0 {
+		if err := c.do(ctx, http.MethodDelete, path, map[string][]string{"reviewers": remove}, nil); err != nil {
+			return err
+		}
+	}
+	if len(add) > 0 {
+		if err := c.do(ctx, http.MethodPost, path, map[string][]string{"reviewers": add}, nil); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// login возвращает логин GitHub пользователя. users.id без сопоставления в
+// GITHUB_LOGINS используются как логин.
+func (c *GitHubClient) login(userID string) string {
+	if login, ok := c.logins[userID]; ok {
+		return login
+	}
+	return userID
+}
+
+func (c *GitHubClient) do(ctx context.Context, method, path string, in, out any) error {
+	var body io.Reader
+	if in != nil {
+		payload, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		var apiErr struct {
+			Message string `json:"message"`
+		}
+		// тело может быть не JSON — тогда в ошибке останется только статус
+		_ = json.Unmarshal(data, &apiErr)
+		return fmt.Errorf("github %s %s responded with status %d: %s", method, path, resp.StatusCode, apiErr.Message)
+	}
+
+	if out != nil {
+		return json.Unmarshal(data, out)
+	}
+	return nil
+}
+
+// parseGitHubPR переводит "<owner>/<repo>#<number>" в путь PR в REST API.
+func parseGitHubPR(prID string) (string, bool) {
+	repo, number, ok := strings.Cut(prID, "#")
+	if !ok {
+		return "", false
+	}
+	owner, name, ok := strings.Cut(repo, "/")
+	if !ok || owner == "" || name == "" || strings.Contains(name, "/") {
+		return "", false
+	}
+	if n, err := strconv.Atoi(number); err != nil || n <= 0 {
+		return "", false
+	}
+
+	return "/repos/" + owner + "/" + name + "/pulls/" + number, true
+}
@@ -0,0 +1,229 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"encoding/json"
+	"errors"
+	"strconv"
+
+	"github.com/RomanKovalev007/pull_request_service/include/models"
+	"github.com/RomanKovalev007/pull_request_service/include/repository"
+	transport "github.com/RomanKovalev007/pull_request_service/include/transport/models"
+)
+
+// Результаты обработки входящего события code host.
+const (
+	IntegrationCreated  = "created"
+	IntegrationReady    = "ready_for_review"
+	IntegrationDraft    = "converted_to_draft"
+	IntegrationMerged   = "merged"
+	IntegrationClosed   = "closed"
+	IntegrationReopened = "reopened"
+	IntegrationIgnored  = "ignored"
+)
+
+var ErrInvalidSignature = errors.New("INVALID_SIGNATURE")
+
+// pullRequestActions — операции PrService, в которые переводятся события code host.
+type pullRequestActions interface {
+	CreatePullRequest(ctx context.Context, req transport.CreatePRRequest) (*transport.CreatePRResponse, error)
+	MergeExternally(ctx context.Context, prID string) (*transport.MergePRResponse, error)
+	MarkReadyForReview(ctx context.Context, req transport.PRStatusRequest) (*transport.PRStatusResponse, error)
+	ClosePullRequest(ctx context.Context, req transport.PRStatusRequest) (*transport.PRStatusResponse, error)
+	ReopenPullRequest(ctx context.Context, req transport.PRStatusRequest) (*transport.PRStatusResponse, error)
+	ConvertToDraft(ctx context.Context, req transport.PRStatusRequest) (*transport.PRStatusResponse, error)
+}
+
+type IntegrationService struct {
+	prs pullRequestActions
+	cfg ReviewerConfig
+}
+
+func NewIntegrationService(prs pullRequestActions, cfg ReviewerConfig) *IntegrationService {
+	return &IntegrationService{prs: prs, cfg: cfg}
+}
+
+// VerifyGitHubSignature проверяет заголовок X-Hub-Signature-256. Без настроенного
+// секрета все события отклоняются.
+func (s *IntegrationService) VerifyGitHubSignature(body []byte, signature string) error {
+	if s.cfg.GitHubWebhookSecret == "" {
+		return &ServiceError{Code: ErrInvalidSignature.Error(), Message: "github webhook secret is not configured"}
+	}
+	if !hmac.Equal([]byte(Sign(s.cfg.GitHubWebhookSecret, body)), []byte(signature)) {
+		return &ServiceError{Code: ErrInvalidSignature.Error(), Message: "invalid X-Hub-Signature-256"}
+	}
+	return nil
+}
+
+// HandleGitHubEvent переводит событие pull_request в вызов PrService. Остальные события
+// и действия игнорируются. ID PR в сервисе — "<owner>/<repo>#<number>".
+func (s *IntegrationService) HandleGitHubEvent(ctx context.Context, eventType string, body []byte) (*transport.IntegrationResponse, error) {
+	if eventType != "pull_request" {
+		return &transport.IntegrationResponse{Result: IntegrationIgnored}, nil
+	}
+
+	var event transport.GitHubPullRequestEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		return nil, &ServiceError{Code: ErrInvalidInput.Error(), Message: "invalid pull_request payload"}
+	}
+	if event.Repository.FullName == "" || event.PullRequest.Number <= 0 {
+		return nil, &ServiceError{Code: ErrInvalidInput.Error(), Message: "repository.full_name and pull_request.number are required"}
+	}
+
+	prID := event.Repository.FullName + "#" + strconv.Itoa(event.PullRequest.Number)
+	ctx = models.WithActor(ctx, "github:"+event.Sender.Login)
+	status := transport.PRStatusRequest{PullRequestID: prID}
+
+	var result string
+	var err error
+	switch {
+	case event.Action == "opened":
+		result = IntegrationCreated
+		_, err = s.prs.CreatePullRequest(ctx, transport.CreatePRRequest{
+			PullRequestID:   prID,
+			PullRequestName: event.PullRequest.Title,
+			AuthorID:        s.githubUserID(event.PullRequest.User.Login),
+			Draft:           event.PullRequest.Draft,
+			Repository:      event.Repository.FullName,
+			SourceBranch:    event.PullRequest.Head.Ref,
+			TargetBranch:    event.PullRequest.Base.Ref,
+			Description:     event.PullRequest.Body,
+			URL:             event.PullRequest.HTMLURL,
+		})
+		// повторная доставка того же события не считается ошибкой
+		if isServiceError(err, repository.ErrPRExists) {
+			result, err = IntegrationIgnored, nil
+		}
+	case event.Action == "ready_for_review":
+		result = IntegrationReady
+		_, err = s.prs.MarkReadyForReview(ctx, status)
+	case event.Action == "converted_to_draft":
+		result = IntegrationDraft
+		_, err = s.prs.ConvertToDraft(ctx, status)
+	case event.Action == "closed" && event.PullRequest.Merged:
+		// PR уже смержен в GitHub, поэтому проверка одобрений не отказывает, а только решает force_merged
+		result = IntegrationMerged
+		_, err = s.prs.MergeExternally(ctx, prID)
+	case event.Action == "closed":
+		result = IntegrationClosed
+		_, err = s.prs.ClosePullRequest(ctx, status)
+	case event.Action == "reopened":
+		result = IntegrationReopened
+		_, err = s.prs.ReopenPullRequest(ctx, status)
+	default:
+		result = IntegrationIgnored
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &transport.IntegrationResponse{Result: result, PullRequestID: prID}, nil
+}
+
+// VerifyGitLabToken проверяет заголовок X-Gitlab-Token. Без настроенного токена
+// все события отклоняются.
+func (s *IntegrationService) VerifyGitLabToken(token string) error {
+	if s.cfg.GitLabWebhookToken == "" {
+		return &ServiceError{Code: ErrInvalidSignature.Error(), Message: "gitlab webhook token is not configured"}
+	}
+	if !hmac.Equal([]byte(s.cfg.GitLabWebhookToken), []byte(token)) {
+		return &ServiceError{Code: ErrInvalidSignature.Error(), Message: "invalid X-Gitlab-Token"}
+	}
+	return nil
+}
+
+// HandleGitLabEvent переводит Merge Request Hook в вызов PrService. IID merge request
+// уникален только в проекте, поэтому ID PR в сервисе — "<namespace>/<project>!<iid>".
+func (s *IntegrationService) HandleGitLabEvent(ctx context.Context, eventType string, body []byte) (*transport.IntegrationResponse, error) {
+	if eventType != "Merge Request Hook" {
+		return &transport.IntegrationResponse{Result: IntegrationIgnored}, nil
+	}
+
+	var event transport.GitLabMergeRequestEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		return nil, &ServiceError{Code: ErrInvalidInput.Error(), Message: "invalid merge request payload"}
+	}
+	attrs := event.ObjectAttributes
+	if event.Project.PathWithNamespace == "" || attrs.IID <= 0 {
+		return nil, &ServiceError{Code: ErrInvalidInput.Error(), Message: "project.path_with_namespace and object_attributes.iid are required"}
+	}
+
+	prID := event.Project.PathWithNamespace + "!" + strconv.Itoa(attrs.IID)
+	ctx = models.WithActor(ctx, "gitlab:"+event.User.Username)
+	status := transport.PRStatusRequest{PullRequestID: prID}
+
+	// старые версии GitLab сообщают о черновике только через work_in_progress
+	draftChange := event.Changes.Draft
+	if draftChange == nil {
+		draftChange = event.Changes.WorkInProgress
+	}
+
+	var result string
+	var err error
+	switch {
+	case attrs.Action == "open":
+		result = IntegrationCreated
+		_, err = s.prs.CreatePullRequest(ctx, transport.CreatePRRequest{
+			PullRequestID:   prID,
+			PullRequestName: attrs.Title,
+			AuthorID:        s.gitlabUserID(event.User.Username),
+			Draft:           attrs.Draft || attrs.WorkInProgress,
+			Repository:      event.Project.PathWithNamespace,
+			SourceBranch:    attrs.SourceBranch,
+			TargetBranch:    attrs.TargetBranch,
+			Description:     attrs.Description,
+			URL:             attrs.URL,
+		})
+		if isServiceError(err, repository.ErrPRExists) {
+			result, err = IntegrationIgnored, nil
+		}
+	case attrs.Action == "update" && draftChange != nil && draftChange.Previous != draftChange.Current:
+		if draftChange.Current {
+			result = IntegrationDraft
+			_, err = s.prs.ConvertToDraft(ctx, status)
+		} else {
+			result = IntegrationReady
+			_, err = s.prs.MarkReadyForReview(ctx, status)
+		}
+	case attrs.Action == "merge":
+		result = IntegrationMerged
+		_, err = s.prs.MergeExternally(ctx, prID)
+	case attrs.Action == "close":
+		result = IntegrationClosed
+		_, err = s.prs.ClosePullRequest(ctx, status)
+	case attrs.Action == "reopen":
+		result = IntegrationReopened
+		_, err = s.prs.ReopenPullRequest(ctx, status)
+	default:
+		result = IntegrationIgnored
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &transport.IntegrationResponse{Result: result, PullRequestID: prID}, nil
+}
+
+// githubUserID возвращает users.id для логина GitHub. Логины без явного
+// сопоставления в GITHUB_LOGINS используются как есть.
+func (s *IntegrationService) githubUserID(login string) string {
+	if userID, ok := s.cfg.GitHubLogins[login]; ok {
+		return userID
+	}
+	return login
+}
+
+// gitlabUserID возвращает users.id для имени пользователя GitLab. Имена без явного
+// сопоставления в GITLAB_USERS используются как есть.
+func (s *IntegrationService) gitlabUserID(username string) string {
+	if userID, ok := s.cfg.GitLabUsers[username]; ok {
+		return userID
+	}
+	return username
+}
+
+func isServiceError(err error, target error) bool {
+	var serviceErr *ServiceError
+	return errors.As(err, &serviceErr) && serviceErr.Code == target.Error()
+}
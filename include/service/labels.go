@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/RomanKovalev007/pull_request_service/include/models"
+	transport "github.com/RomanKovalev007/pull_request_service/include/transport/models"
+)
+
+const maxTagLength = 50
+
+// normalizeTags приводит теги и метки к нижнему регистру, убирает пробелы по краям
+// и дубликаты и сортирует их, чтобы `DB` и `db` считались одним тегом.
+func normalizeTags(tags []string) []string {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	normalized := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		normalized = append(normalized, strings.ToLower(strings.TrimSpace(tag)))
+	}
+	slices.Sort(normalized)
+	return slices.Compact(normalized)
+}
+
+// labelPreference отбирает одного кандидата, у которого есть тег из меток PR.
+// Если меток нет, возвращает nil.
+func labelPreference(labels []string) *models.ReviewerPreference {
+	if len(labels) == 0 {
+		return nil
+	}
+
+	return &models.ReviewerPreference{
+		Reason: models.SelectedByLabel,
+		Match: func(c models.ReviewerCandidate) bool {
+			return slices.ContainsFunc(c.Tags, func(tag string) bool { return slices.Contains(labels, tag) })
+		},
+		Limit: 1,
+	}
+}
+
+// reviewerPreferences собирает правила выбора ревьюверов PR: сначала гарантируется
+// ревьювер с тегом из меток PR, затем выбираются владельцы изменённых файлов.
+func (s *PrService) reviewerPreferences(ctx context.Context, authorID string, labels, changedFiles []string) ([]models.ReviewerPreference, error) {
+	var prefs []models.ReviewerPreference
+	if pref := labelPreference(labels); pref != nil {
+		prefs = append(prefs, *pref)
+	}
+
+	pref, err := s.codeOwnersPreference(ctx, authorID, changedFiles)
+	if err != nil {
+		return nil, err
+	}
+	if pref != nil {
+		prefs = append(prefs, *pref)
+	}
+
+	return prefs, nil
+}
+
+// AddPullRequestLabels добавляет метки PR. Уже назначенные ревьюверы не меняются:
+// метки учитываются при следующем назначении, например когда черновик готов к ревью.
+func (s *PrService) AddPullRequestLabels(ctx context.Context, req transport.PRLabelsRequest) (*transport.PRLabelsResponse, error) {
+	if err := s.validatePRLabels(req); err != nil {
+		return nil, err
+	}
+
+	pr, err := s.prRepo.AddPullRequestLabels(ctx, req.PullRequestID, normalizeTags(req.Labels))
+	if err != nil {
+		return nil, &ServiceError{Code: err.Error(), Message: "failed to add pull request labels"}
+	}
+
+	return &transport.PRLabelsResponse{PullRequest: *pr}, nil
+}
+
+func (s *PrService) RemovePullRequestLabels(ctx context.Context, req transport.PRLabelsRequest) (*transport.PRLabelsResponse, error) {
+	if err := s.validatePRLabels(req); err != nil {
+		return nil, err
+	}
+
+	pr, err := s.prRepo.RemovePullRequestLabels(ctx, req.PullRequestID, normalizeTags(req.Labels))
+	if err != nil {
+		return nil, &ServiceError{Code: err.Error(), Message: "failed to remove pull request labels"}
+	}
+
+	return &transport.PRLabelsResponse{PullRequest: *pr}, nil
+}
+
+func (s *UserService) AddUserTags(ctx context.Context, req transport.UserTagsRequest) (*transport.UserResponse, error) {
+	if err := s.validateUserTags(req); err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.AddUserTags(ctx, req.UserID, normalizeTags(req.Tags))
+	if err != nil {
+		return nil, &ServiceError{Code: err.Error(), Message: "failed to add user tags"}
+	}
+
+	return &transport.UserResponse{User: *user}, nil
+}
+
+func (s *UserService) RemoveUserTags(ctx context.Context, req transport.UserTagsRequest) (*transport.UserResponse, error) {
+	if err := s.validateUserTags(req); err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.RemoveUserTags(ctx, req.UserID, normalizeTags(req.Tags))
+	if err != nil {
+		return nil, &ServiceError{Code: err.Error(), Message: "failed to remove user tags"}
+	}
+
+	return &transport.UserResponse{User: *user}, nil
+}
@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+	"slices"
+
+	"github.com/RomanKovalev007/pull_request_service/include/models"
+	"github.com/RomanKovalev007/pull_request_service/include/repository"
+	transport "github.com/RomanKovalev007/pull_request_service/include/transport/models"
+)
+
+// prTransitions — допустимые переходы статусов PR. MERGED — конечный статус.
+var prTransitions = map[string][]string{
+	models.StatusDraft:  {models.StatusOpen, models.StatusClosed},
+	models.StatusOpen:   {models.StatusMerged, models.StatusClosed, models.StatusDraft},
+	models.StatusClosed: {models.StatusOpen},
+}
+
+func checkTransition(from, to string) *ServiceError {
+	if from == models.StatusMerged {
+		return &ServiceError{Code: repository.ErrPRMerged.Error(), Message: "pull request is already merged"}
+	}
+	if !slices.Contains(prTransitions[from], to) {
+		return &ServiceError{Code: repository.ErrInvalidTransition.Error(), Message: "cannot move pull request from " + from + " to " + to}
+	}
+	return nil
+}
+
+func (s *PrService) MarkReadyForReview(ctx context.Context, req transport.PRStatusRequest) (*transport.PRStatusResponse, error) {
+	return s.openPullRequest(ctx, req, models.StatusDraft, "failed to mark pull request ready for review")
+}
+
+func (s *PrService) ReopenPullRequest(ctx context.Context, req transport.PRStatusRequest) (*transport.PRStatusResponse, error) {
+	return s.openPullRequest(ctx, req, models.StatusClosed, "failed to reopen pull request")
+}
+
+func (s *PrService) ClosePullRequest(ctx context.Context, req transport.PRStatusRequest) (*transport.PRStatusResponse, error) {
+	if err := s.validatePRStatusRequest(req); err != nil {
+		return nil, err
+	}
+
+	status, err := s.prRepo.GetPullRequestStatus(ctx, req.PullRequestID)
+	if err != nil {
+		return nil, &ServiceError{Code: err.Error(), Message: "failed to close pull request"}
+	}
+	if err := checkTransition(status, models.StatusClosed); err != nil {
+		return nil, err
+	}
+
+	pr, err := s.prRepo.ClosePullRequest(ctx, req.PullRequestID, status)
+	if err != nil {
+		return nil, &ServiceError{Code: err.Error(), Message: "failed to close pull request"}
+	}
+
+	return &transport.PRStatusResponse{PullRequest: *pr}, nil
+}
+
+// ConvertToDraft возвращает открытый PR в черновик, назначенные ревьюверы снимаются.
+func (s *PrService) ConvertToDraft(ctx context.Context, req transport.PRStatusRequest) (*transport.PRStatusResponse, error) {
+	if err := s.validatePRStatusRequest(req); err != nil {
+		return nil, err
+	}
+
+	status, err := s.prRepo.GetPullRequestStatus(ctx, req.PullRequestID)
+	if err != nil {
+		return nil, &ServiceError{Code: err.Error(), Message: "failed to convert pull request to draft"}
+	}
+	if err := checkTransition(status, models.StatusDraft); err != nil {
+		return nil, err
+	}
+
+	pr, err := s.prRepo.ConvertToDraft(ctx, req.PullRequestID)
+	if err != nil {
+		return nil, &ServiceError{Code: err.Error(), Message: "failed to convert pull request to draft"}
+	}
+
+	return &transport.PRStatusResponse{PullRequest: *pr}, nil
+}
+
+// openPullRequest переводит PR в OPEN, только если он сейчас в статусе from.
+func (s *PrService) openPullRequest(ctx context.Context, req transport.PRStatusRequest, from, message string) (*transport.PRStatusResponse, error) {
+	if err := s.validatePRStatusRequest(req); err != nil {
+		return nil, err
+	}
+
+	current, err := s.prRepo.GetPullRequest(ctx, req.PullRequestID)
+	if err != nil {
+		return nil, &ServiceError{Code: err.Error(), Message: message}
+	}
+	if err := checkTransition(current.Status, models.StatusOpen); err != nil {
+		return nil, err
+	}
+	if current.Status != from {
+		return nil, &ServiceError{Code: repository.ErrInvalidTransition.Error(), Message: "pull request is not " + from}
+	}
+
+	prefs, err := s.reviewerPreferences(ctx, current.AuthorID, current.Labels, current.ChangedFiles)
+	if err != nil {
+		return nil, &ServiceError{Code: err.Error(), Message: message}
+	}
+
+	pr, err := s.prRepo.OpenPullRequest(ctx, req.PullRequestID, current.Status, s.selector.Select, prefs)
+	if err != nil {
+		return nil, &ServiceError{Code: err.Error(), Message: message}
+	}
+	s.reviewerSync.Push(ctx, pr)
+
+	return &transport.PRStatusResponse{PullRequest: *pr}, nil
+}
@@ -8,17 +8,36 @@ import (
 )
 
 type prRepository interface {
-	CreatePullRequest(ctx context.Context, req models.PullRequestShort) (*models.PullRequest, error)
-	MergePullRequest(ctx context.Context, prID string) (*models.PullRequest, error)
-	ReassignReviewer(ctx context.Context, prID, oldUserID string) (*models.PullRequest, string, error)
+	CreatePullRequest(ctx context.Context, req models.PullRequest, pick models.ReviewerPickFunc, prefs []models.ReviewerPreference) (*models.PullRequest, error)
+	MergePullRequest(ctx context.Context, prID string, force, external bool) (*models.PullRequest, bool, error)
+	ReassignReviewer(ctx context.Context, prID, oldUserID string, pick models.ReviewerPickFunc) (*models.PullRequest, string, error)
+	SubmitReview(ctx context.Context, prID string, review models.Review) (*models.PullRequest, *models.Review, error)
+
+	GetPullRequestStatus(ctx context.Context, prID string) (string, error)
+	OpenPullRequest(ctx context.Context, prID, from string, pick models.ReviewerPickFunc, prefs []models.ReviewerPreference) (*models.PullRequest, error)
+	ClosePullRequest(ctx context.Context, prID, from string) (*models.PullRequest, error)
+	ConvertToDraft(ctx context.Context, prID string) (*models.PullRequest, error)
+
+	GetPullRequest(ctx context.Context, prID string) (*models.PullRequest, error)
+	ListPullRequests(ctx context.Context, filter models.PullRequestFilter) ([]models.PullRequest, int, error)
+	GetAssignmentHistory(ctx context.Context, prID string) ([]models.AssignmentEvent, error)
+	GetAuthorCodeOwners(ctx context.Context, authorID string) (*models.TeamCodeOwners, error)
+
+	UpdatePullRequest(ctx context.Context, prID string, upd models.PullRequestUpdate) (*models.PullRequest, error)
+	AddPullRequestLabels(ctx context.Context, prID string, labels []string) (*models.PullRequest, error)
+	RemovePullRequestLabels(ctx context.Context, prID string, labels []string) (*models.PullRequest, error)
 }
 
 type PrService struct {
-	prRepo prRepository
+	prRepo   prRepository
+	selector *StrategySelector
+	// reviewerSync выгружает назначения в code host; nil, если выгрузка не настроена.
+	reviewerSync *ReviewerSyncService
+	cfg          ReviewerConfig
 }
 
-func NewPrService(prRepo prRepository) *PrService {
-	return &PrService{prRepo: prRepo}
+func NewPrService(prRepo prRepository, selector *StrategySelector, reviewerSync *ReviewerSyncService, cfg ReviewerConfig) *PrService {
+	return &PrService{prRepo: prRepo, selector: selector, reviewerSync: reviewerSync, cfg: cfg}
 }
 
 func (s *PrService) CreatePullRequest(ctx context.Context, req transport.CreatePRRequest) (*transport.CreatePRResponse, error) {
@@ -26,16 +45,38 @@ func (s *PrService) CreatePullRequest(ctx context.Context, req transport.CreateP
 		return nil, err
 	}
 
-	req_pr := models.PullRequestShort{
+	req_pr := models.PullRequest{
 		PullRequestID:   req.PullRequestID,
 		PullRequestName: req.PullRequestName,
 		AuthorID:        req.AuthorID,
+		Status:          models.StatusOpen,
+		ChangedFiles:    req.ChangedFiles,
+		Labels:          normalizeTags(req.Labels),
+		Repository:      req.Repository,
+		SourceBranch:    req.SourceBranch,
+		TargetBranch:    req.TargetBranch,
+		Description:     req.Description,
+		URL:             req.URL,
+	}
+	if req.Draft {
+		req_pr.Status = models.StatusDraft
+	}
+
+	var prefs []models.ReviewerPreference
+	if req_pr.Status == models.StatusOpen {
+		var err error
+		if prefs, err = s.reviewerPreferences(ctx, req.AuthorID, req_pr.Labels, req.ChangedFiles); err != nil {
+			return nil, &ServiceError{Code: err.Error(), Message: "failed to create pull request"}
+		}
 	}
 
-	pr, err := s.prRepo.CreatePullRequest(ctx, req_pr)
+	pr, err := s.prRepo.CreatePullRequest(ctx, req_pr, s.pickFunc(req.Strategy), prefs)
 	if err != nil {
 		return nil, &ServiceError{Code: err.Error(), Message: "failed to create pull request"}
 	}
+	if pr.Status == models.StatusOpen {
+		s.reviewerSync.Push(ctx, pr)
+	}
 
 	resp := transport.CreatePRResponse{PullRequest: *pr}
 
@@ -47,12 +88,29 @@ func (s *PrService) MergePullRequest(ctx context.Context, req transport.MergePRR
 		return nil, err
 	}
 
-	pr, err := s.prRepo.MergePullRequest(ctx, req.PullRequestID)
+	pr, merged, err := s.prRepo.MergePullRequest(ctx, req.PullRequestID, req.Force, false)
 	if err != nil {
 		return nil, &ServiceError{Code: err.Error(), Message: "failed to merge pull request"}
 	}
 
-	resp := transport.MergePRResponse{PullRequest: *pr}
+	resp := transport.MergePRResponse{PullRequest: *pr, Merged: merged}
+
+	return &resp, nil
+}
+
+// MergeExternally фиксирует merge, уже выполненный в code host: проверка одобрений не отказывает,
+// PR помечается merged_externally, а force_merged — только если проверка отказала бы.
+func (s *PrService) MergeExternally(ctx context.Context, prID string) (*transport.MergePRResponse, error) {
+	if err := s.validateMergePR(transport.MergePRRequest{PullRequestID: prID}); err != nil {
+		return nil, err
+	}
+
+	pr, merged, err := s.prRepo.MergePullRequest(ctx, prID, false, true)
+	if err != nil {
+		return nil, &ServiceError{Code: err.Error(), Message: "failed to merge pull request"}
+	}
+
+	resp := transport.MergePRResponse{PullRequest: *pr, Merged: merged}
 
 	return &resp, nil
 }
@@ -62,12 +120,119 @@ func (s *PrService) ReassignReviewer(ctx context.Context, req transport.Reassign
 		return nil, err
 	}
 
-	pr, newID, err := s.prRepo.ReassignReviewer(ctx, req.PullRequestID, req.OldUserID)
+	pr, newID, err := s.prRepo.ReassignReviewer(ctx, req.PullRequestID, req.OldUserID, s.pickFunc(req.Strategy))
 	if err != nil {
 		return nil, &ServiceError{Code: err.Error(), Message: "failed to reassign pull request"}
 	}
+	s.reviewerSync.Push(ctx, pr)
 
 	resp := transport.ReassignResponse{PullRequest: *pr, ReplacedBy: newID}
 
 	return &resp, nil
 }
+
+func (s *PrService) SubmitReview(ctx context.Context, req transport.ReviewRequest) (*transport.ReviewResponse, error) {
+	if err := s.validateSubmitReview(req); err != nil {
+		return nil, err
+	}
+
+	review := models.Review{
+		ReviewerID: req.ReviewerID,
+		State:      req.State,
+		Comment:    req.Comment,
+	}
+
+	pr, result, err := s.prRepo.SubmitReview(ctx, req.PullRequestID, review)
+	if err != nil {
+		return nil, &ServiceError{Code: err.Error(), Message: "failed to submit review"}
+	}
+
+	return &transport.ReviewResponse{PullRequest: *pr, Review: *result}, nil
+}
+
+// pickFunc возвращает стратегию, явно запрошенную клиентом, или стратегию команды по умолчанию.
+func (s *PrService) pickFunc(strategy string) models.ReviewerPickFunc {
+	if selector, ok := s.selector.Strategy(strategy); ok {
+		return selector.Select
+	}
+	return s.selector.Select
+}
+
+func (s *PrService) GetPullRequest(ctx context.Context, prID string) (*transport.PRGetResponse, error) {
+	if err := s.validatePRStatusRequest(transport.PRStatusRequest{PullRequestID: prID}); err != nil {
+		return nil, err
+	}
+
+	pr, err := s.prRepo.GetPullRequest(ctx, prID)
+	if err != nil {
+		return nil, &ServiceError{Code: err.Error(), Message: "failed to get pull request"}
+	}
+
+	return &transport.PRGetResponse{PullRequest: *pr}, nil
+}
+
+func (s *PrService) GetAssignmentHistory(ctx context.Context, prID string) (*transport.PRHistoryResponse, error) {
+	if err := s.validatePRStatusRequest(transport.PRStatusRequest{PullRequestID: prID}); err != nil {
+		return nil, err
+	}
+
+	events, err := s.prRepo.GetAssignmentHistory(ctx, prID)
+	if err != nil {
+		return nil, &ServiceError{Code: err.Error(), Message: "failed to get assignment history"}
+	}
+
+	return &transport.PRHistoryResponse{PullRequestID: prID, Events: events}, nil
+}
+
+// UpdatePullRequest меняет описание PR. Новые метки не переназначают уже выбранных ревьюверов.
+func (s *PrService) UpdatePullRequest(ctx context.Context, req transport.PRUpdateRequest) (*transport.PRUpdateResponse, error) {
+	if err := s.validateUpdatePR(req); err != nil {
+		return nil, err
+	}
+
+	upd := models.PullRequestUpdate{
+		PullRequestName: req.PullRequestName,
+		Repository:      req.Repository,
+		SourceBranch:    req.SourceBranch,
+		TargetBranch:    req.TargetBranch,
+		Description:     req.Description,
+		URL:             req.URL,
+	}
+	if req.Labels != nil {
+		// пустой список снимает все метки
+		labels := normalizeTags(*req.Labels)
+		if labels == nil {
+			labels = []string{}
+		}
+		upd.Labels = &labels
+	}
+
+	pr, err := s.prRepo.UpdatePullRequest(ctx, req.PullRequestID, upd)
+	if err != nil {
+		return nil, &ServiceError{Code: err.Error(), Message: "failed to update pull request"}
+	}
+
+	return &transport.PRUpdateResponse{PullRequest: *pr}, nil
+}
+
+func (s *PrService) ListPullRequests(ctx context.Context, filter models.PullRequestFilter) (*transport.PRListResponse, error) {
+	if filter.Limit == 0 {
+		filter.Limit = defaultPageLimit
+	}
+	if err := s.validateListPullRequests(filter); err != nil {
+		return nil, err
+	}
+	filter.Labels = normalizeTags(filter.Labels)
+
+	prs, total, err := s.prRepo.ListPullRequests(ctx, filter)
+	if err != nil {
+		return nil, &ServiceError{Code: err.Error(), Message: "failed to list pull requests"}
+	}
+
+	return &transport.PRListResponse{
+		PullRequests: prs,
+		Total:        total,
+		Limit:        filter.Limit,
+		Offset:       filter.Offset,
+	}, nil
+}
@@ -0,0 +1,108 @@
+package service
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/RomanKovalev007/pull_request_service/include/models"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	RosterYAML = "yaml"
+	RosterCSV  = "csv"
+)
+
+// rosterRecord повторяет RosterEntry, но отличает отсутствующий is_active от false.
+type rosterRecord struct {
+	TeamName string `yaml:"team"`
+	UserID   string `yaml:"user_id"`
+	Username string `yaml:"username"`
+	IsActive *bool  `yaml:"is_active"`
+}
+
+// ParseRoster читает оргструктуру в формате yaml (список записей) или csv (с заголовком
+// team,user_id,username[,is_active]). Пользователь без is_active считается активным.
+func ParseRoster(r io.Reader, format string) ([]models.RosterEntry, error) {
+	var records []rosterRecord
+	var err error
+
+	switch format {
+	case RosterYAML:
+		err = yaml.NewDecoder(r).Decode(&records)
+		if errors.Is(err, io.EOF) {
+			err = nil
+		}
+	case RosterCSV:
+		records, err = parseRosterCSV(r)
+	default:
+		return nil, fmt.Errorf("unknown roster format %q", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]models.RosterEntry, 0, len(records))
+	for _, rec := range records {
+		entry := models.RosterEntry{TeamName: rec.TeamName, UserID: rec.UserID, Username: rec.Username, IsActive: true}
+		if rec.IsActive != nil {
+			entry.IsActive = *rec.IsActive
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+func parseRosterCSV(r io.Reader) ([]rosterRecord, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	columns := make(map[string]int)
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range []string{"team", "user_id", "username"} {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("missing column %q", name)
+		}
+	}
+
+	var records []rosterRecord
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		rec := rosterRecord{
+			TeamName: row[columns["team"]],
+			UserID:   row[columns["user_id"]],
+			Username: row[columns["username"]],
+		}
+		if i, ok := columns["is_active"]; ok && row[i] != "" {
+			isActive, err := strconv.ParseBool(row[i])
+			if err != nil {
+				line, _ := reader.FieldPos(i)
+				return nil, fmt.Errorf("line %d: invalid is_active %q", line, row[i])
+			}
+			rec.IsActive = &isActive
+		}
+		records = append(records, rec)
+	}
+
+	return records, nil
+}
@@ -0,0 +1,185 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"sort"
+	"sync"
+
+	"github.com/RomanKovalev007/pull_request_service/include/models"
+)
+
+const (
+	StrategyRandom      = "random"
+	StrategyLeastLoaded = "least_loaded"
+	StrategyRoundRobin  = "round_robin"
+	StrategyWeighted    = "weighted"
+)
+
+// ReviewerSelector выбирает ревьюверов из подходящих кандидатов.
+// Кандидаты уже отфильтрованы репозиторием: активные, не автор и не назначенные на PR.
+type ReviewerSelector interface {
+	Select(teamName string, candidates []models.ReviewerCandidate, count int) []string
+}
+
+func NewReviewerSelector(cfg ReviewerConfig) (*StrategySelector, error) {
+	strategies := map[string]ReviewerSelector{
+		StrategyRandom:      randomSelector{},
+		StrategyLeastLoaded: leastLoadedSelector{},
+		StrategyRoundRobin:  &roundRobinSelector{last: make(map[string]string)},
+		StrategyWeighted:    weightedSelector{weights: cfg.ReviewerWeights},
+	}
+
+	name := cfg.ReviewerStrategy
+	if name == "" {
+		name = StrategyRandom
+	}
+
+	def, ok := strategies[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown reviewer strategy %q", name)
+	}
+
+	selector := &StrategySelector{def: def, strategies: strategies, teams: make(map[string]ReviewerSelector)}
+	for team, name := range cfg.TeamReviewerStrategies {
+		strategy, ok := strategies[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown reviewer strategy %q for team %q", name, team)
+		}
+		selector.teams[team] = strategy
+	}
+
+	return selector, nil
+}
+
+// StrategySelector применяет стратегию команды, а для остальных команд — глобальную.
+type StrategySelector struct {
+	def        ReviewerSelector
+	strategies map[string]ReviewerSelector
+	teams      map[string]ReviewerSelector
+}
+
+// Strategy возвращает встроенную стратегию по имени, например для выбора в конкретном запросе.
+func (s *StrategySelector) Strategy(name string) (ReviewerSelector, bool) {
+	strategy, ok := s.strategies[name]
+	return strategy, ok
+}
+
+func (s *StrategySelector) Select(teamName string, candidates []models.ReviewerCandidate, count int) []string {
+	if strategy, ok := s.teams[teamName]; ok {
+		return strategy.Select(teamName, candidates, count)
+	}
+	return s.def.Select(teamName, candidates, count)
+}
+
+type randomSelector struct{}
+
+func (randomSelector) Select(_ string, candidates []models.ReviewerCandidate, count int) []string {
+	return firstIDs(shuffled(candidates), count)
+}
+
+// leastLoadedSelector предпочитает кандидатов с наименьшим числом открытых ревью.
+// При равной нагрузке выигрывает тот, кого дольше всех не назначали.
+type leastLoadedSelector struct{}
+
+func (leastLoadedSelector) Select(_ string, candidates []models.ReviewerCandidate, count int) []string {
+	ordered := shuffled(candidates)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		a, b := ordered[i], ordered[j]
+		if a.OpenReviews != b.OpenReviews {
+			return a.OpenReviews < b.OpenReviews
+		}
+		if a.LastAssignedAt == nil || b.LastAssignedAt == nil {
+			return a.LastAssignedAt == nil && b.LastAssignedAt != nil
+		}
+		return a.LastAssignedAt.Before(*b.LastAssignedAt)
+	})
+	return firstIDs(ordered, count)
+}
+
+// roundRobinSelector обходит участников команды по порядку id,
+// продолжая с места последнего назначения. Позиция хранится в памяти процесса.
+type roundRobinSelector struct {
+	mu   sync.Mutex
+	last map[string]string
+}
+
+func (s *roundRobinSelector) Select(teamName string, candidates []models.ReviewerCandidate, count int) []string {
+	if len(candidates) == 0 || count <= 0 {
+		return nil
+	}
+
+	ordered := slices.Clone(candidates)
+	sort.Slice(ordered, func(i, j int) bool {
+		return ordered[i].UserID < ordered[j].UserID
+	})
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	start := sort.Search(len(ordered), func(i int) bool {
+		return ordered[i].UserID > s.last[teamName]
+	})
+
+	n := min(count, len(ordered))
+	ids := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		ids = append(ids, ordered[(start+i)%len(ordered)].UserID)
+	}
+	s.last[teamName] = ids[len(ids)-1]
+
+	return ids
+}
+
+// weightedSelector делает случайную выборку без повторений пропорционально весам пользователей.
+// Пользователи без веса имеют вес 1, с неположительным весом не назначаются.
+type weightedSelector struct {
+	weights map[string]int
+}
+
+func (s weightedSelector) Select(_ string, candidates []models.ReviewerCandidate, count int) []string {
+	type keyed struct {
+		id  string
+		key float64
+	}
+
+	var pool []keyed
+	for _, c := range candidates {
+		weight, ok := s.weights[c.UserID]
+		if !ok {
+			weight = 1
+		}
+		if weight <= 0 {
+			continue
+		}
+		pool = append(pool, keyed{id: c.UserID, key: math.Pow(rand.Float64(), 1/float64(weight))})
+	}
+
+	sort.Slice(pool, func(i, j int) bool {
+		return pool[i].key > pool[j].key
+	})
+
+	ids := make([]string, 0, min(count, len(pool)))
+	for i := 0; i < len(pool) && i < count; i++ {
+		ids = append(ids, pool[i].id)
+	}
+	return ids
+}
+
+func shuffled(candidates []models.ReviewerCandidate) []models.ReviewerCandidate {
+	result := slices.Clone(candidates)
+	rand.Shuffle(len(result), func(i, j int) {
+		result[i], result[j] = result[j], result[i]
+	})
+	return result
+}
+
+func firstIDs(candidates []models.ReviewerCandidate, count int) []string {
+	ids := make([]string, 0, min(count, len(candidates)))
+	for i := 0; i < len(candidates) && i < count; i++ {
+		ids = append(ids, candidates[i].UserID)
+	}
+	return ids
+}
@@ -48,9 +48,14 @@ func (s *StatsService) GetStats(ctx context.Context) (*models.StatsResponse, err
 	}
 
 	for _, pr := range prStats {
-		if pr.Status == "MERGED" {
+		switch pr.Status {
+		case models.StatusMerged:
 			totalStats.MergedPRs += 1
-		} else {
+		case models.StatusDraft:
+			totalStats.DraftPRs += 1
+		case models.StatusClosed:
+			totalStats.ClosedPRs += 1
+		default:
 			totalStats.OpenPRs += 1
 		}
 	}
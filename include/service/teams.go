@@ -8,16 +8,27 @@ import (
 )
 
 type teamRepository interface {
-	CreateTeam(ctx context.Context, team models.Team) (*models.Team, error)
+	CreateTeam(ctx context.Context, team models.Team, pick models.ReviewerPickFunc) (*models.Team, error)
 	GetTeam(ctx context.Context, teamName string) (*models.Team, error)
+	GetTeamSettings(ctx context.Context, teamName string) (*models.TeamSettings, error)
+	SetTeamSettings(ctx context.Context, teamName string, upd models.TeamSettingsUpdate) (*models.TeamSettings, error)
+	GetTeamCodeOwners(ctx context.Context, teamName string) (*models.TeamCodeOwners, error)
+	SetTeamCodeOwners(ctx context.Context, owners models.TeamCodeOwners) (*models.TeamCodeOwners, error)
+
+	ListTeams(ctx context.Context) ([]models.Team, error)
+	RenameTeam(ctx context.Context, teamName, newTeamName string) (*models.Team, error)
+	DeleteTeam(ctx context.Context, teamName, policy string, pick models.ReviewerPickFunc) ([]string, []models.ReviewHandoff, error)
+	AddTeamMembers(ctx context.Context, teamName string, members []models.TeamMember, pick models.ReviewerPickFunc) (*models.Team, error)
+	RemoveTeamMembers(ctx context.Context, teamName string, userIDs []string, pick models.ReviewerPickFunc) (*models.Team, error)
 }
 
 type TeamService struct {
 	teamRepo teamRepository
+	selector *StrategySelector
 }
 
-func NewTeamService(teamRepo teamRepository) *TeamService {
-	return &TeamService{teamRepo: teamRepo}
+func NewTeamService(teamRepo teamRepository, selector *StrategySelector) *TeamService {
+	return &TeamService{teamRepo: teamRepo, selector: selector}
 }
 
 func (s *TeamService) CreateTeam(ctx context.Context, req models.Team) (*transport.TeamCreateResponse, error) {
@@ -25,7 +36,7 @@ func (s *TeamService) CreateTeam(ctx context.Context, req models.Team) (*transpo
 		return nil, err
 	}
 
-	team, err := s.teamRepo.CreateTeam(ctx, req)
+	team, err := s.teamRepo.CreateTeam(ctx, req, s.selector.Select)
 	if err != nil {
 		return nil, &ServiceError{Code: err.Error(), Message: "failed to create team"}
 	}
@@ -44,3 +55,123 @@ func (s *TeamService) GetTeam(ctx context.Context, teamName string) (*models.Tea
 	}
 	return team, nil
 }
+
+func (s *TeamService) GetTeamSettings(ctx context.Context, teamName string) (*models.TeamSettings, error) {
+	if err := s.validateGetTeam(teamName); err != nil {
+		return nil, err
+	}
+
+	settings, err := s.teamRepo.GetTeamSettings(ctx, teamName)
+	if err != nil {
+		return nil, &ServiceError{Code: err.Error(), Message: "failed to get team settings"}
+	}
+	return settings, nil
+}
+
+// SetTeamSettings меняет только переданные настройки: клиент, не знающий о новых полях,
+// не сбрасывает их значения.
+func (s *TeamService) SetTeamSettings(ctx context.Context, req transport.TeamSettingsRequest) (*transport.TeamSettingsResponse, error) {
+	if err := s.validateSettingsRequest(req); err != nil {
+		return nil, err
+	}
+
+	current, err := s.teamRepo.GetTeamSettings(ctx, req.TeamName)
+	if err != nil {
+		return nil, &ServiceError{Code: err.Error(), Message: "failed to set team settings"}
+	}
+
+	// ограничения проверяются на итоговых настройках
+	merged := *current
+	if req.ReviewerCount != nil {
+		merged.ReviewerCount = *req.ReviewerCount
+	}
+	if req.MinReviewers != nil {
+		merged.MinReviewers = *req.MinReviewers
+	}
+	if req.RequiredApprovals != nil {
+		merged.RequiredApprovals = *req.RequiredApprovals
+	}
+	if req.FallbackTeams != nil {
+		merged.FallbackTeams = *req.FallbackTeams
+	}
+	if err := s.validateSetTeamSettings(merged); err != nil {
+		return nil, err
+	}
+
+	upd := models.TeamSettingsUpdate{
+		ReviewerCount:     req.ReviewerCount,
+		MinReviewers:      req.MinReviewers,
+		RequiredApprovals: req.RequiredApprovals,
+		FallbackTeams:     req.FallbackTeams,
+	}
+	settings, err := s.teamRepo.SetTeamSettings(ctx, req.TeamName, upd)
+	if err != nil {
+		return nil, &ServiceError{Code: err.Error(), Message: "failed to set team settings"}
+	}
+
+	return &transport.TeamSettingsResponse{Settings: *settings}, nil
+}
+
+func (s *TeamService) ListTeams(ctx context.Context) (*transport.TeamListResponse, error) {
+	teams, err := s.teamRepo.ListTeams(ctx)
+	if err != nil {
+		return nil, &ServiceError{Code: err.Error(), Message: "failed to list teams"}
+	}
+
+	return &transport.TeamListResponse{Teams: teams}, nil
+}
+
+func (s *TeamService) RenameTeam(ctx context.Context, req transport.TeamRenameRequest) (*transport.TeamResponse, error) {
+	if err := s.validateRenameTeam(req); err != nil {
+		return nil, err
+	}
+
+	team, err := s.teamRepo.RenameTeam(ctx, req.TeamName, req.NewTeamName)
+	if err != nil {
+		return nil, &ServiceError{Code: err.Error(), Message: "failed to rename team"}
+	}
+
+	return &transport.TeamResponse{Team: *team}, nil
+}
+
+func (s *TeamService) DeleteTeam(ctx context.Context, req transport.TeamDeleteRequest) (*transport.TeamDeleteResponse, error) {
+	if req.OpenReviews == "" {
+		req.OpenReviews = models.OpenReviewsReassign
+	}
+	if err := s.validateDeleteTeam(req); err != nil {
+		return nil, err
+	}
+
+	released, affected, err := s.teamRepo.DeleteTeam(ctx, req.TeamName, req.OpenReviews, s.selector.Select)
+	if err != nil {
+		return nil, &ServiceError{Code: err.Error(), Message: "failed to delete team"}
+	}
+
+	return &transport.TeamDeleteResponse{TeamName: req.TeamName, ReleasedMembers: released, AffectedPullRequests: affected}, nil
+}
+
+func (s *TeamService) AddTeamMembers(ctx context.Context, req models.Team) (*transport.TeamResponse, error) {
+	if err := s.validateCreateTeam(req); err != nil {
+		return nil, err
+	}
+
+	team, err := s.teamRepo.AddTeamMembers(ctx, req.TeamName, req.Members, s.selector.Select)
+	if err != nil {
+		return nil, &ServiceError{Code: err.Error(), Message: "failed to add team members"}
+	}
+
+	return &transport.TeamResponse{Team: *team}, nil
+}
+
+func (s *TeamService) RemoveTeamMembers(ctx context.Context, req transport.TeamMembersRemoveRequest) (*transport.TeamResponse, error) {
+	if err := s.validateRemoveTeamMembers(req); err != nil {
+		return nil, err
+	}
+
+	team, err := s.teamRepo.RemoveTeamMembers(ctx, req.TeamName, req.UserIDs, s.selector.Select)
+	if err != nil {
+		return nil, &ServiceError{Code: err.Error(), Message: "failed to remove team members"}
+	}
+
+	return &transport.TeamResponse{Team: *team}, nil
+}
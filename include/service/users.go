@@ -8,16 +8,32 @@ import (
 )
 
 type userRepository interface {
-	SetUserIsActive(ctx context.Context, userID string, isActive bool) (*models.User, error)
-	GetUserPullRequests(ctx context.Context, userID string) ([]models.PullRequestShort, error)
+	SetUserIsActive(ctx context.Context, userID string, isActive bool, pick models.ReviewerPickFunc) (*models.User, error)
+	GetUserPullRequests(ctx context.Context, userID string, filter models.ReviewFilter) ([]models.PullRequestShort, *models.PageCursor, error)
+	SetUserCapacity(ctx context.Context, userID string, capacity *int) (*models.User, error)
+
+	CreateAvailability(ctx context.Context, a models.Availability) (*models.Availability, error)
+	GetUserAvailability(ctx context.Context, userID string) ([]models.Availability, error)
+	UpdateAvailability(ctx context.Context, a models.Availability) (*models.Availability, error)
+	DeleteAvailability(ctx context.Context, id int64) error
+	ReassignUnavailableReviews(ctx context.Context, pick models.ReviewerPickFunc) (int, error)
+	MoveUserTeam(ctx context.Context, userID, teamName, mode string, pick models.ReviewerPickFunc) (*models.User, []models.ReviewHandoff, error)
+
+	GetUser(ctx context.Context, userID string) (*models.User, error)
+	ListUsers(ctx context.Context, filter models.UserFilter) ([]models.User, error)
+	CreateUser(ctx context.Context, user models.User) (*models.User, error)
+	UpdateUser(ctx context.Context, userID string, upd models.UserUpdate, pick models.ReviewerPickFunc) (*models.User, []models.ReviewHandoff, error)
+	AddUserTags(ctx context.Context, userID string, tags []string) (*models.User, error)
+	RemoveUserTags(ctx context.Context, userID string, tags []string) (*models.User, error)
 }
 
 type UserService struct {
 	userRepo userRepository
+	selector *StrategySelector
 }
 
-func NewUserService(userRepo userRepository) *UserService {
-	return &UserService{userRepo: userRepo}
+func NewUserService(userRepo userRepository, selector *StrategySelector) *UserService {
+	return &UserService{userRepo: userRepo, selector: selector}
 }
 
 func (s *UserService) SetUserIsActive(ctx context.Context, req transport.UserSetActiveRequest) (*transport.UserSetActiveResponse, error) {
@@ -25,7 +41,7 @@ func (s *UserService) SetUserIsActive(ctx context.Context, req transport.UserSet
 		return nil, &ServiceError{Code: err.Error(), Message: "failed to set user active status"}
 	}
 
-	user, err := s.userRepo.SetUserIsActive(ctx, req.UserID, req.IsActive)
+	user, err := s.userRepo.SetUserIsActive(ctx, req.UserID, req.IsActive, s.selector.Select)
 	if err != nil {
 		return nil, &ServiceError{Code: err.Error(), Message: "failed to set user active status"}
 	}
@@ -37,20 +53,125 @@ func (s *UserService) SetUserIsActive(ctx context.Context, req transport.UserSet
 	return &resp, nil
 }
 
-func (s *UserService) GetUserPullRequests(ctx context.Context, userID string) (*transport.UserPRsResponse, error) {
-	if err := s.validateGetUserPullRequests(userID); err != nil {
-		return nil, &ServiceError{Code: err.Error(), Message: "failed to set user active status"}
+func (s *UserService) SetUserCapacity(ctx context.Context, req transport.UserSetCapacityRequest) (*transport.UserSetCapacityResponse, error) {
+	if err := s.validateSetUserCapacity(req); err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.SetUserCapacity(ctx, req.UserID, req.Capacity)
+	if err != nil {
+		return nil, &ServiceError{Code: err.Error(), Message: "failed to set user review capacity"}
+	}
+
+	return &transport.UserSetCapacityResponse{User: *user}, nil
+}
+
+func (s *UserService) GetUserPullRequests(ctx context.Context, req transport.UserPRsRequest) (*transport.UserPRsResponse, error) {
+	if req.Limit == 0 {
+		req.Limit = defaultPageLimit
+	}
+	if err := s.validateUserPRsRequest(req); err != nil {
+		return nil, err
+	}
+
+	filter := models.ReviewFilter{Status: req.Status, Since: req.Since, Limit: req.Limit}
+	if req.Cursor != "" {
+		after, err := decodeCursor(req.Cursor)
+		if err != nil {
+			return nil, &ServiceError{Code: ErrInvalidInput.Error(), Message: "invalid cursor"}
+		}
+		filter.After = after
 	}
 
-	prs, err := s.userRepo.GetUserPullRequests(ctx, userID)
+	prs, next, err := s.userRepo.GetUserPullRequests(ctx, req.UserID, filter)
 	if err != nil {
 		return nil, &ServiceError{Code: err.Error(), Message: "failed to get user pull requests"}
 	}
 
 	resp := transport.UserPRsResponse{
-		UserID:       userID,
+		UserID:       req.UserID,
 		PullRequests: prs,
 	}
+	if next != nil {
+		resp.NextCursor = encodeCursor(*next)
+	}
 
 	return &resp, nil
 }
+
+func (s *UserService) MoveUserTeam(ctx context.Context, req transport.UserMoveTeamRequest) (*transport.UserMoveTeamResponse, error) {
+	if req.Reviews == "" {
+		req.Reviews = models.HandoffReassign
+	}
+	if err := s.validateMoveUserTeam(req); err != nil {
+		return nil, err
+	}
+
+	user, report, err := s.userRepo.MoveUserTeam(ctx, req.UserID, req.TeamName, req.Reviews, s.selector.Select)
+	if err != nil {
+		return nil, &ServiceError{Code: err.Error(), Message: "failed to move user"}
+	}
+
+	return &transport.UserMoveTeamResponse{User: *user, AffectedPullRequests: report}, nil
+}
+
+func (s *UserService) GetUser(ctx context.Context, userID string) (*transport.UserResponse, error) {
+	if err := s.validateUserID(userID); err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.GetUser(ctx, userID)
+	if err != nil {
+		return nil, &ServiceError{Code: err.Error(), Message: "failed to get user"}
+	}
+
+	return &transport.UserResponse{User: *user}, nil
+}
+
+func (s *UserService) ListUsers(ctx context.Context, filter models.UserFilter) (*transport.UserListResponse, error) {
+	users, err := s.userRepo.ListUsers(ctx, filter)
+	if err != nil {
+		return nil, &ServiceError{Code: err.Error(), Message: "failed to list users"}
+	}
+
+	return &transport.UserListResponse{Users: users}, nil
+}
+
+func (s *UserService) CreateUser(ctx context.Context, req transport.UserCreateRequest) (*transport.UserResponse, error) {
+	if err := s.validateCreateUser(req); err != nil {
+		return nil, err
+	}
+
+	user := models.User{
+		UserID:         req.UserID,
+		Username:       req.Username,
+		TeamName:       req.TeamName,
+		IsActive:       true,
+		ReviewCapacity: req.ReviewCapacity,
+		Tags:           normalizeTags(req.Tags),
+	}
+	if req.IsActive != nil {
+		user.IsActive = *req.IsActive
+	}
+
+	created, err := s.userRepo.CreateUser(ctx, user)
+	if err != nil {
+		return nil, &ServiceError{Code: err.Error(), Message: "failed to create user"}
+	}
+
+	return &transport.UserResponse{User: *created}, nil
+}
+
+func (s *UserService) UpdateUser(ctx context.Context, req transport.UserUpdateRequest) (*transport.UserUpdateResponse, error) {
+	if err := s.validateUpdateUser(req); err != nil {
+		return nil, err
+	}
+
+	upd := models.UserUpdate{Username: req.Username, TeamName: req.TeamName, IsActive: req.IsActive}
+	user, affected, err := s.userRepo.UpdateUser(ctx, req.UserID, upd, s.selector.Select)
+	if err != nil {
+		return nil, &ServiceError{Code: err.Error(), Message: "failed to update user"}
+	}
+
+	return &transport.UserUpdateResponse{User: *user, AffectedPullRequests: affected}, nil
+}
@@ -1,10 +1,23 @@
 package service
 
 import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
 	"github.com/RomanKovalev007/pull_request_service/include/models"
 	transport "github.com/RomanKovalev007/pull_request_service/include/transport/models"
 )
 
+const (
+	maxReviewerCount = 10
+	defaultPageLimit = 50
+	maxPageLimit     = 100
+	// maxMetadataLength — длина колонок repository, source_branch и target_branch.
+	maxMetadataLength = 255
+)
+
 func (s *TeamService) validateCreateTeam(team models.Team) *ServiceError {
 	if team.TeamName == "" {
 		return &ServiceError{Code: ErrInvalidInput.Error(), Message: "team_name is required"}
@@ -38,6 +51,106 @@ func (s *TeamService) validateGetTeam(teamName string) *ServiceError {
 	return nil
 }
 
+func (s *TeamService) validateRenameTeam(req transport.TeamRenameRequest) *ServiceError {
+	if req.TeamName == "" || req.NewTeamName == "" {
+		return &ServiceError{Code: ErrInvalidInput.Error(), Message: "team_name and new_team_name are required"}
+	}
+	if req.TeamName == req.NewTeamName {
+		return &ServiceError{Code: ErrInvalidInput.Error(), Message: "new_team_name must differ from team_name"}
+	}
+	return nil
+}
+
+func (s *TeamService) validateDeleteTeam(req transport.TeamDeleteRequest) *ServiceError {
+	if req.TeamName == "" {
+		return &ServiceError{Code: ErrInvalidInput.Error(), Message: "team_name is required"}
+	}
+	switch req.OpenReviews {
+	case models.OpenReviewsReassign, models.OpenReviewsReject, models.OpenReviewsDrop:
+	default:
+		return &ServiceError{Code: ErrInvalidInput.Error(), Message: "open_reviews must be reassign, reject or drop"}
+	}
+	return nil
+}
+
+func (s *TeamService) validateRemoveTeamMembers(req transport.TeamMembersRemoveRequest) *ServiceError {
+	if req.TeamName == "" {
+		return &ServiceError{Code: ErrInvalidInput.Error(), Message: "team_name is required"}
+	}
+	if len(req.UserIDs) == 0 {
+		return &ServiceError{Code: ErrInvalidInput.Error(), Message: "user_ids must not be empty"}
+	}
+
+	seen := make(map[string]bool)
+	for _, userID := range req.UserIDs {
+		if userID == "" {
+			return &ServiceError{Code: ErrInvalidInput.Error(), Message: "user_id must not be empty"}
+		}
+		if seen[userID] {
+			return &ServiceError{Code: ErrInvalidInput.Error(), Message: "duplicate user_id in user_ids"}
+		}
+		seen[userID] = true
+	}
+	return nil
+}
+
+func (s *TeamService) validateSetTeamCodeOwners(owners models.TeamCodeOwners) *ServiceError {
+	if owners.TeamName == "" {
+		return &ServiceError{Code: ErrInvalidInput.Error(), Message: "team_name is required"}
+	}
+	if _, err := ParseCodeOwners(owners.Content); err != nil {
+		return &ServiceError{Code: ErrInvalidInput.Error(), Message: "invalid codeowners: " + err.Error()}
+	}
+	return nil
+}
+
+func (s *TeamService) validateSettingsRequest(req transport.TeamSettingsRequest) *ServiceError {
+	if req.TeamName == "" {
+		return &ServiceError{Code: ErrInvalidInput.Error(), Message: "team_name is required"}
+	}
+	if req.ReviewerCount == nil && req.MinReviewers == nil && req.RequiredApprovals == nil && req.FallbackTeams == nil {
+		return &ServiceError{Code: ErrInvalidInput.Error(), Message: "nothing to update"}
+	}
+	// переданные вместе значения проверяются до чтения текущих настроек
+	if req.ReviewerCount != nil {
+		count := *req.ReviewerCount
+		if req.MinReviewers != nil && *req.MinReviewers > count {
+			return &ServiceError{Code: ErrInvalidInput.Error(), Message: "min_reviewers must be between 0 and reviewer_count"}
+		}
+		if req.RequiredApprovals != nil && *req.RequiredApprovals > count {
+			return &ServiceError{Code: ErrInvalidInput.Error(), Message: "required_approvals must be between 0 and reviewer_count"}
+		}
+	}
+	return nil
+}
+
+func (s *TeamService) validateSetTeamSettings(settings models.TeamSettings) *ServiceError {
+	if settings.TeamName == "" {
+		return &ServiceError{Code: ErrInvalidInput.Error(), Message: "team_name is required"}
+	}
+	if settings.ReviewerCount < 1 || settings.ReviewerCount > maxReviewerCount {
+		return &ServiceError{Code: ErrInvalidInput.Error(), Message: "reviewer_count must be between 1 and 10"}
+	}
+	if settings.MinReviewers < 0 || settings.MinReviewers > settings.ReviewerCount {
+		return &ServiceError{Code: ErrInvalidInput.Error(), Message: "min_reviewers must be between 0 and reviewer_count"}
+	}
+	if settings.RequiredApprovals < 0 || settings.RequiredApprovals > settings.ReviewerCount {
+		return &ServiceError{Code: ErrInvalidInput.Error(), Message: "required_approvals must be between 0 and reviewer_count"}
+	}
+
+	fallbacks := make(map[string]bool)
+	for _, fallback := range settings.FallbackTeams {
+		if fallback == "" || fallback == settings.TeamName {
+			return &ServiceError{Code: ErrInvalidInput.Error(), Message: "fallback team must differ from the team"}
+		}
+		if fallbacks[fallback] {
+			return &ServiceError{Code: ErrInvalidInput.Error(), Message: "duplicate fallback team"}
+		}
+		fallbacks[fallback] = true
+	}
+	return nil
+}
+
 func (s *UserService) validateSetUserIsActive(req transport.UserSetActiveRequest) *ServiceError {
 	if req.UserID == "" {
 		return &ServiceError{Code: ErrInvalidInput.Error(), Message: "user_id is required"}
@@ -45,13 +158,109 @@ func (s *UserService) validateSetUserIsActive(req transport.UserSetActiveRequest
 	return nil
 }
 
-func (s *UserService) validateGetUserPullRequests(userID string) *ServiceError {
+func (s *UserService) validateSetUserCapacity(req transport.UserSetCapacityRequest) *ServiceError {
+	if req.UserID == "" {
+		return &ServiceError{Code: ErrInvalidInput.Error(), Message: "user_id is required"}
+	}
+	if req.Capacity != nil && *req.Capacity < 0 {
+		return &ServiceError{Code: ErrInvalidInput.Error(), Message: "capacity must not be negative"}
+	}
+	return nil
+}
+
+func (s *UserService) validateAvailability(a models.Availability) *ServiceError {
+	if a.UserID == "" {
+		return &ServiceError{Code: ErrInvalidInput.Error(), Message: "user_id is required"}
+	}
+	return s.validateAvailabilityPeriod(a)
+}
+
+func (s *UserService) validateAvailabilityPeriod(a models.Availability) *ServiceError {
+	if a.StartsAt.IsZero() || a.EndsAt.IsZero() {
+		return &ServiceError{Code: ErrInvalidInput.Error(), Message: "starts_at and ends_at are required"}
+	}
+	if !a.EndsAt.After(a.StartsAt) {
+		return &ServiceError{Code: ErrInvalidInput.Error(), Message: "ends_at must be after starts_at"}
+	}
+	return nil
+}
+
+func (s *UserService) validateUserID(userID string) *ServiceError {
 	if userID == "" {
 		return &ServiceError{Code: ErrInvalidInput.Error(), Message: "user_id is required"}
 	}
 	return nil
 }
 
+func (s *UserService) validateUserPRsRequest(req transport.UserPRsRequest) *ServiceError {
+	if err := s.validateUserID(req.UserID); err != nil {
+		return err
+	}
+	switch req.Status {
+	case "", models.StatusDraft, models.StatusOpen, models.StatusMerged, models.StatusClosed:
+	default:
+		return &ServiceError{Code: ErrInvalidInput.Error(), Message: "unknown status " + req.Status}
+	}
+	if req.Limit < 1 || req.Limit > maxPageLimit {
+		return &ServiceError{Code: ErrInvalidInput.Error(), Message: "limit must be between 1 and 100"}
+	}
+	return nil
+}
+
+func (s *UserService) validateCreateUser(req transport.UserCreateRequest) *ServiceError {
+	if req.UserID == "" {
+		return &ServiceError{Code: ErrInvalidInput.Error(), Message: "user_id is required"}
+	}
+	if req.Username == "" {
+		return &ServiceError{Code: ErrInvalidInput.Error(), Message: "username is required"}
+	}
+	if req.TeamName == "" {
+		return &ServiceError{Code: ErrInvalidInput.Error(), Message: "team_name is required"}
+	}
+	if req.ReviewCapacity != nil && *req.ReviewCapacity < 0 {
+		return &ServiceError{Code: ErrInvalidInput.Error(), Message: "review_capacity must not be negative"}
+	}
+	return validateTags("tags", req.Tags)
+}
+
+func (s *UserService) validateUpdateUser(req transport.UserUpdateRequest) *ServiceError {
+	if req.UserID == "" {
+		return &ServiceError{Code: ErrInvalidInput.Error(), Message: "user_id is required"}
+	}
+	if req.Username == nil && req.TeamName == nil && req.IsActive == nil {
+		return &ServiceError{Code: ErrInvalidInput.Error(), Message: "nothing to update"}
+	}
+	if req.Username != nil && *req.Username == "" {
+		return &ServiceError{Code: ErrInvalidInput.Error(), Message: "username must not be empty"}
+	}
+	if req.TeamName != nil && *req.TeamName == "" {
+		return &ServiceError{Code: ErrInvalidInput.Error(), Message: "team_name must not be empty"}
+	}
+	return nil
+}
+
+func (s *UserService) validateUserTags(req transport.UserTagsRequest) *ServiceError {
+	if req.UserID == "" {
+		return &ServiceError{Code: ErrInvalidInput.Error(), Message: "user_id is required"}
+	}
+	if len(req.Tags) == 0 {
+		return &ServiceError{Code: ErrInvalidInput.Error(), Message: "tags are required"}
+	}
+	return validateTags("tags", req.Tags)
+}
+
+func (s *UserService) validateMoveUserTeam(req transport.UserMoveTeamRequest) *ServiceError {
+	if req.UserID == "" || req.TeamName == "" {
+		return &ServiceError{Code: ErrInvalidInput.Error(), Message: "user_id and team_name are required"}
+	}
+	switch req.Reviews {
+	case models.HandoffKeep, models.HandoffReassign, models.HandoffDrop:
+	default:
+		return &ServiceError{Code: ErrInvalidInput.Error(), Message: "reviews must be keep, reassign or drop"}
+	}
+	return nil
+}
+
 func (s *PrService) validateCreatePR(req transport.CreatePRRequest) *ServiceError {
 	if req.PullRequestID == "" {
 		return &ServiceError{Code: ErrInvalidInput.Error(), Message: "pull_request_id is required"}
@@ -62,10 +271,88 @@ func (s *PrService) validateCreatePR(req transport.CreatePRRequest) *ServiceErro
 	if req.AuthorID == "" {
 		return &ServiceError{Code: ErrInvalidInput.Error(), Message: "author_id is required"}
 	}
+	if err := s.validateStrategy(req.Strategy); err != nil {
+		return err
+	}
+	if slices.Contains(req.ChangedFiles, "") {
+		return &ServiceError{Code: ErrInvalidInput.Error(), Message: "changed_files must not contain empty paths"}
+	}
+	if err := validatePRMetadata(&req.Repository, &req.SourceBranch, &req.TargetBranch, &req.URL); err != nil {
+		return err
+	}
+
+	return validateTags("labels", req.Labels)
+}
+
+func (s *PrService) validateUpdatePR(req transport.PRUpdateRequest) *ServiceError {
+	if req.PullRequestID == "" {
+		return &ServiceError{Code: ErrInvalidInput.Error(), Message: "pull_request_id is required"}
+	}
+	if req.PullRequestName == nil && req.Repository == nil && req.SourceBranch == nil && req.TargetBranch == nil &&
+		req.Description == nil && req.URL == nil && req.Labels == nil {
+		return &ServiceError{Code: ErrInvalidInput.Error(), Message: "nothing to update"}
+	}
+	if req.PullRequestName != nil && *req.PullRequestName == "" {
+		return &ServiceError{Code: ErrInvalidInput.Error(), Message: "pull_request_name must not be empty"}
+	}
+	if err := validatePRMetadata(req.Repository, req.SourceBranch, req.TargetBranch, req.URL); err != nil {
+		return err
+	}
+	if req.Labels != nil {
+		return validateTags("labels", *req.Labels)
+	}
 
 	return nil
 }
 
+// validatePRMetadata проверяет репозиторий, ветки и ссылку PR; nil и пустые значения допустимы.
+func validatePRMetadata(repository, sourceBranch, targetBranch, prURL *string) *ServiceError {
+	fields := []struct {
+		name  string
+		value *string
+	}{
+		{"repository", repository},
+		{"source_branch", sourceBranch},
+		{"target_branch", targetBranch},
+	}
+	for _, field := range fields {
+		if field.value != nil && len(*field.value) > maxMetadataLength {
+			return &ServiceError{Code: ErrInvalidInput.Error(), Message: fmt.Sprintf("%s must be at most %d characters long", field.name, maxMetadataLength)}
+		}
+	}
+	if prURL != nil && *prURL != "" {
+		u, err := url.Parse(*prURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return &ServiceError{Code: ErrInvalidInput.Error(), Message: "url must be an absolute http(s) URL"}
+		}
+	}
+	return nil
+}
+
+func (s *PrService) validatePRLabels(req transport.PRLabelsRequest) *ServiceError {
+	if req.PullRequestID == "" {
+		return &ServiceError{Code: ErrInvalidInput.Error(), Message: "pull_request_id is required"}
+	}
+	if len(req.Labels) == 0 {
+		return &ServiceError{Code: ErrInvalidInput.Error(), Message: "labels are required"}
+	}
+	return validateTags("labels", req.Labels)
+}
+
+// validateTags проверяет теги пользователя или метки PR, field — имя поля в запросе.
+func validateTags(field string, tags []string) *ServiceError {
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if tag == "" {
+			return &ServiceError{Code: ErrInvalidInput.Error(), Message: field + " must not contain empty values"}
+		}
+		if len(tag) > maxTagLength {
+			return &ServiceError{Code: ErrInvalidInput.Error(), Message: fmt.Sprintf("%s must be at most %d characters long", field, maxTagLength)}
+		}
+	}
+	return nil
+}
+
 func (s *PrService) validateMergePR(req transport.MergePRRequest) *ServiceError {
 	if req.PullRequestID == "" {
 		return &ServiceError{Code: ErrInvalidInput.Error(), Message: "pull_request_id is required"}
@@ -74,6 +361,39 @@ func (s *PrService) validateMergePR(req transport.MergePRRequest) *ServiceError
 	return nil
 }
 
+func (s *PrService) validatePRStatusRequest(req transport.PRStatusRequest) *ServiceError {
+	if req.PullRequestID == "" {
+		return &ServiceError{Code: ErrInvalidInput.Error(), Message: "pull_request_id is required"}
+	}
+
+	return nil
+}
+
+func (s *PrService) validateListPullRequests(filter models.PullRequestFilter) *ServiceError {
+	switch filter.Status {
+	case "", models.StatusDraft, models.StatusOpen, models.StatusMerged, models.StatusClosed:
+	default:
+		return &ServiceError{Code: ErrInvalidInput.Error(), Message: "unknown status " + filter.Status}
+	}
+	if filter.Limit < 1 || filter.Limit > maxPageLimit {
+		return &ServiceError{Code: ErrInvalidInput.Error(), Message: "limit must be between 1 and 100"}
+	}
+	if filter.Offset < 0 {
+		return &ServiceError{Code: ErrInvalidInput.Error(), Message: "offset must not be negative"}
+	}
+	if filter.CreatedFrom != nil && filter.CreatedTo != nil && !filter.CreatedFrom.Before(*filter.CreatedTo) {
+		return &ServiceError{Code: ErrInvalidInput.Error(), Message: "created_from must be before created_to"}
+	}
+	if filter.MergedFrom != nil && filter.MergedTo != nil && !filter.MergedFrom.Before(*filter.MergedTo) {
+		return &ServiceError{Code: ErrInvalidInput.Error(), Message: "merged_from must be before merged_to"}
+	}
+	if err := validateTags("labels", filter.Labels); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (s *PrService) validateReassignReviewer(req transport.ReassignRequest) *ServiceError {
 	if req.PullRequestID == "" {
 		return &ServiceError{Code: ErrInvalidInput.Error(), Message: "pull_request_id is required"}
@@ -82,6 +402,106 @@ func (s *PrService) validateReassignReviewer(req transport.ReassignRequest) *Ser
 	if req.OldUserID == "" {
 		return &ServiceError{Code: ErrInvalidInput.Error(), Message: "old_user_id is required"}
 	}
+	if err := s.validateStrategy(req.Strategy); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *PrService) validateSubmitReview(req transport.ReviewRequest) *ServiceError {
+	if req.PullRequestID == "" {
+		return &ServiceError{Code: ErrInvalidInput.Error(), Message: "pull_request_id is required"}
+	}
+	if req.ReviewerID == "" {
+		return &ServiceError{Code: ErrInvalidInput.Error(), Message: "reviewer_id is required"}
+	}
+
+	switch req.State {
+	case models.ReviewApproved, models.ReviewChangesRequested, models.ReviewCommented:
+	default:
+		return &ServiceError{Code: ErrInvalidInput.Error(), Message: "state must be one of APPROVED, CHANGES_REQUESTED, COMMENTED"}
+	}
+
+	return nil
+}
+
+func (s *PrService) validateStrategy(strategy string) *ServiceError {
+	if strategy == "" {
+		return nil
+	}
+	if _, ok := s.selector.Strategy(strategy); !ok {
+		return &ServiceError{Code: ErrInvalidInput.Error(), Message: "unknown reviewer strategy"}
+	}
+	return nil
+}
+
+func (s *AdminService) validateImportRoster(roster []models.RosterEntry) *ServiceError {
+	if len(roster) == 0 {
+		return &ServiceError{Code: ErrInvalidInput.Error(), Message: "roster must not be empty"}
+	}
+
+	userIDs := make(map[string]bool)
+	for _, entry := range roster {
+		if entry.TeamName == "" || entry.UserID == "" || entry.Username == "" {
+			return &ServiceError{Code: ErrInvalidInput.Error(), Message: "team, user_id and username are required for all roster entries"}
+		}
+		if userIDs[entry.UserID] {
+			return &ServiceError{Code: ErrInvalidInput.Error(), Message: "duplicate user_id " + entry.UserID + " in roster"}
+		}
+		userIDs[entry.UserID] = true
+	}
+
+	return nil
+}
+
+func (s *AdminService) validateRestoreSnapshot(snap models.Snapshot) *ServiceError {
+	if snap.Version != models.SnapshotVersion {
+		return &ServiceError{Code: ErrInvalidInput.Error(), Message: fmt.Sprintf("unsupported snapshot version %d", snap.Version)}
+	}
+
+	teams := make(map[string]bool)
+	for _, team := range snap.Teams {
+		if team.TeamName == "" {
+			return &ServiceError{Code: ErrInvalidInput.Error(), Message: "team_name is required for all teams"}
+		}
+		teams[team.TeamName] = true
+	}
+
+	users := make(map[string]bool)
+	for _, user := range snap.Users {
+		if user.UserID == "" || user.Username == "" {
+			return &ServiceError{Code: ErrInvalidInput.Error(), Message: "user_id and username are required for all users"}
+		}
+		if user.TeamName != "" && !teams[user.TeamName] {
+			return &ServiceError{Code: ErrInvalidInput.Error(), Message: "user " + user.UserID + " refers to unknown team " + user.TeamName}
+		}
+		users[user.UserID] = true
+	}
+
+	for _, pr := range snap.PullRequests {
+		if pr.PullRequestID == "" || pr.PullRequestName == "" {
+			return &ServiceError{Code: ErrInvalidInput.Error(), Message: "pull_request_id and pull_request_name are required for all pull requests"}
+		}
+		if !users[pr.AuthorID] {
+			return &ServiceError{Code: ErrInvalidInput.Error(), Message: "pull request " + pr.PullRequestID + " refers to unknown author " + pr.AuthorID}
+		}
+	}
+
+	return nil
+}
+
+func (s *WebhookService) validateCreateWebhook(req transport.WebhookCreateRequest) *ServiceError {
+	u, err := url.Parse(req.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return &ServiceError{Code: ErrInvalidInput.Error(), Message: "url must be an absolute http(s) URL"}
+	}
+
+	for _, event := range req.Events {
+		if !slices.Contains(models.EventTypes, event) {
+			return &ServiceError{Code: ErrInvalidInput.Error(), Message: "unknown event type " + event}
+		}
+	}
 
 	return nil
 }
@@ -0,0 +1,282 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/RomanKovalev007/pull_request_service/include/models"
+	transport "github.com/RomanKovalev007/pull_request_service/include/transport/models"
+)
+
+const (
+	dispatchBatchSize = 100
+	// dispatchConcurrency — сколько доставок отправляется одновременно.
+	dispatchConcurrency = 10
+	// deliveryLease — на сколько откладывается выбранная доставка, пока идёт отправка.
+	deliveryLease = time.Minute
+
+	SignatureHeader = "X-Webhook-Signature"
+	EventHeader     = "X-Webhook-Event"
+	DeliveryHeader  = "X-Webhook-Delivery"
+)
+
+type webhookRepository interface {
+	CreateWebhook(ctx context.Context, hook models.Webhook) (*models.Webhook, error)
+	ListWebhooks(ctx context.Context) ([]models.Webhook, error)
+	DeleteWebhook(ctx context.Context, id int64) error
+
+	FanOutEvents(ctx context.Context, limit int) (int, error)
+	ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]models.WebhookDelivery, error)
+	MarkDelivered(ctx context.Context, id int64, statusCode int) error
+	MarkFailed(ctx context.Context, id int64, statusCode *int, lastError string, nextAttemptAt *time.Time) error
+
+	ListDeadDeliveries(ctx context.Context, webhookID int64) ([]models.WebhookDelivery, error)
+	RetryDelivery(ctx context.Context, id int64) error
+}
+
+type WebhookService struct {
+	webhookRepo webhookRepository
+	client      *http.Client
+	cfg         ReviewerConfig
+}
+
+func NewWebhookService(webhookRepo webhookRepository, cfg ReviewerConfig) *WebhookService {
+	return &WebhookService{
+		webhookRepo: webhookRepo,
+		client:      &http.Client{Timeout: cfg.WebhookTimeout},
+		cfg:         cfg,
+	}
+}
+
+// CreateWebhook регистрирует подписчика. Если секрет не передан, он генерируется
+// и возвращается только в этом ответе.
+func (s *WebhookService) CreateWebhook(ctx context.Context, req transport.WebhookCreateRequest) (*transport.WebhookResponse, error) {
+	if err := s.validateCreateWebhook(req); err != nil {
+		return nil, err
+	}
+
+	secret := req.Secret
+	if secret == "" {
+		buf := make([]byte, 32)
+		if _, err := rand.Read(buf); err != nil {
+			return nil, &ServiceError{Code: err.Error(), Message: "failed to generate webhook secret"}
+		}
+		secret = hex.EncodeToString(buf)
+	}
+
+	events := req.Events
+	if events == nil {
+		events = []string{}
+	}
+
+	hook, err := s.webhookRepo.CreateWebhook(ctx, models.Webhook{URL: req.URL, Secret: secret, Events: events})
+	if err != nil {
+		return nil, &ServiceError{Code: err.Error(), Message: "failed to create webhook"}
+	}
+
+	return &transport.WebhookResponse{Webhook: *hook}, nil
+}
+
+func (s *WebhookService) ListWebhooks(ctx context.Context) (*transport.WebhookListResponse, error) {
+	hooks, err := s.webhookRepo.ListWebhooks(ctx)
+	if err != nil {
+		return nil, &ServiceError{Code: err.Error(), Message: "failed to list webhooks"}
+	}
+
+	return &transport.WebhookListResponse{Webhooks: hooks}, nil
+}
+
+func (s *WebhookService) DeleteWebhook(ctx context.Context, req transport.WebhookDeleteRequest) error {
+	if req.ID <= 0 {
+		return &ServiceError{Code: ErrInvalidInput.Error(), Message: "id is required"}
+	}
+
+	if err := s.webhookRepo.DeleteWebhook(ctx, req.ID); err != nil {
+		return &ServiceError{Code: err.Error(), Message: "failed to delete webhook"}
+	}
+
+	return nil
+}
+
+func (s *WebhookService) ListDeadDeliveries(ctx context.Context, webhookID int64) (*transport.DeadDeliveriesResponse, error) {
+	if webhookID < 0 {
+		return nil, &ServiceError{Code: ErrInvalidInput.Error(), Message: "webhook_id must be positive"}
+	}
+
+	deliveries, err := s.webhookRepo.ListDeadDeliveries(ctx, webhookID)
+	if err != nil {
+		return nil, &ServiceError{Code: err.Error(), Message: "failed to list dead deliveries"}
+	}
+
+	return &transport.DeadDeliveriesResponse{Deliveries: deliveries}, nil
+}
+
+func (s *WebhookService) RetryDelivery(ctx context.Context, req transport.DeliveryRetryRequest) error {
+	if req.DeliveryID <= 0 {
+		return &ServiceError{Code: ErrInvalidInput.Error(), Message: "delivery_id is required"}
+	}
+
+	if err := s.webhookRepo.RetryDelivery(ctx, req.DeliveryID); err != nil {
+		return &ServiceError{Code: err.Error(), Message: "failed to retry delivery"}
+	}
+
+	return nil
+}
+
+// RunDispatcher периодически раскладывает события outbox по подписчикам и отправляет
+// доставки, время которых наступило. Возвращает управление после отмены ctx,
+// неположительный интервал отключает рассылку.
+func (s *WebhookService) RunDispatcher(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.Dispatch(ctx); err != nil {
+				log.Printf("failed to dispatch webhooks: %v", err)
+			}
+		}
+	}
+}
+
+// Dispatch выполняет один проход рассылки. Доставки отправляются параллельно,
+// а их число ограничено так, чтобы проход укладывался в deliveryLease.
+func (s *WebhookService) Dispatch(ctx context.Context) error {
+	if _, err := s.webhookRepo.FanOutEvents(ctx, dispatchBatchSize); err != nil {
+		return err
+	}
+
+	limit := leaseBatchSize(deliveryLease, s.cfg.WebhookTimeout, dispatchConcurrency, dispatchBatchSize)
+	deliveries, err := s.webhookRepo.ClaimDueDeliveries(ctx, limit, deliveryLease)
+	if err != nil {
+		return err
+	}
+
+	queue := make(chan models.WebhookDelivery)
+	errs := make(chan error, len(deliveries))
+
+	var wg sync.WaitGroup
+	for range min(dispatchConcurrency, len(deliveries)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for d := range queue {
+				if err := s.deliver(ctx, d); err != nil {
+					errs <- err
+				}
+			}
+		}()
+	}
+
+	for _, d := range deliveries {
+		queue <- d
+	}
+	close(queue)
+	wg.Wait()
+	close(errs)
+
+	return <-errs
+}
+
+// leaseBatchSize возвращает, сколько задач можно взять за проход, чтобы concurrency
+// исполнителей успели обработать их до истечения lease, даже если каждая занимает
+// perTask. Неположительный perTask означает отсутствие таймаута — берётся limit.
+func leaseBatchSize(lease, perTask time.Duration, concurrency, limit int) int {
+	if perTask <= 0 {
+		return limit
+	}
+
+	// одну задачу на исполнителя оставляем в запасе на запись результатов
+	rounds := max(int(lease/perTask)-1, 1)
+	return min(limit, rounds*concurrency)
+}
+
+// deliver отправляет доставку и сохраняет результат попытки.
+func (s *WebhookService) deliver(ctx context.Context, d models.WebhookDelivery) error {
+	statusCode, err := s.send(ctx, d)
+	if err == nil {
+		return s.webhookRepo.MarkDelivered(ctx, d.ID, statusCode)
+	}
+
+	var code *int
+	if statusCode != 0 {
+		code = &statusCode
+	}
+
+	var next *time.Time
+	if attempt := d.Attempts + 1; attempt < s.cfg.WebhookMaxAttempts {
+		at := time.Now().Add(s.backoff(attempt))
+		next = &at
+	}
+
+	return s.webhookRepo.MarkFailed(ctx, d.ID, code, err.Error(), next)
+}
+
+func (s *WebhookService) backoff(attempt int) time.Duration {
+	return backoffDelay(s.cfg.WebhookBackoffBase, s.cfg.WebhookBackoffMax, attempt)
+}
+
+// backoffDelay возвращает задержку перед следующей попыткой: base * 2^(attempt-1), не больше max.
+func backoffDelay(base, max time.Duration, attempt int) time.Duration {
+	delay := base
+	for i := 1; i < attempt && delay < max; i++ {
+		delay *= 2
+	}
+	return min(delay, max)
+}
+
+func (s *WebhookService) send(ctx context.Context, d models.WebhookDelivery) (int, error) {
+	body, err := json.Marshal(d.Event)
+	if err != nil {
+		return 0, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, d.Event.Type)
+	req.Header.Set(DeliveryHeader, strconv.FormatInt(d.ID, 10))
+	req.Header.Set(SignatureHeader, Sign(d.Secret, body))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	// тело ответа не нужно, вычитываем его, чтобы соединение вернулось в пул
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("subscriber responded with status %d", resp.StatusCode)
+	}
+
+	return resp.StatusCode, nil
+}
+
+// Sign возвращает подпись тела запроса в формате sha256=<hex HMAC-SHA256>.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,18 @@
+package transport
+
+import "github.com/RomanKovalev007/pull_request_service/include/models"
+
+type ImportRequest struct {
+	Roster []models.RosterEntry
+	DryRun bool
+}
+
+type ImportResponse struct {
+	Plan models.ImportPlan `json:"plan"`
+}
+
+type RestoreResponse struct {
+	Teams        int `json:"teams"`
+	Users        int `json:"users"`
+	PullRequests int `json:"pull_requests"`
+}
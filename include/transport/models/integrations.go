@@ -0,0 +1,67 @@
+package transport
+
+// GitHubPullRequestEvent — поля события pull_request из GitHub, которые использует сервис.
+type GitHubPullRequestEvent struct {
+	Action      string `json:"action"`
+	Number      int    `json:"number"`
+	PullRequest struct {
+		Number  int    `json:"number"`
+		Title   string `json:"title"`
+		Body    string `json:"body"`
+		HTMLURL string `json:"html_url"`
+		Draft   bool   `json:"draft"`
+		Merged  bool   `json:"merged"`
+		User    struct {
+			Login string `json:"login"`
+		} `json:"user"`
+		Head struct {
+			Ref string `json:"ref"`
+		} `json:"head"`
+		Base struct {
+			Ref string `json:"ref"`
+		} `json:"base"`
+	} `json:"pull_request"`
+	Repository struct {
+		FullName string `json:"full_name"`
+	} `json:"repository"`
+	Sender struct {
+		Login string `json:"login"`
+	} `json:"sender"`
+}
+
+// GitLabMergeRequestEvent — поля события Merge Request Hook из GitLab, которые использует сервис.
+type GitLabMergeRequestEvent struct {
+	ObjectKind string `json:"object_kind"`
+	User       struct {
+		Username string `json:"username"`
+	} `json:"user"`
+	Project struct {
+		PathWithNamespace string `json:"path_with_namespace"`
+	} `json:"project"`
+	ObjectAttributes struct {
+		IID            int    `json:"iid"`
+		Title          string `json:"title"`
+		Description    string `json:"description"`
+		URL            string `json:"url"`
+		SourceBranch   string `json:"source_branch"`
+		TargetBranch   string `json:"target_branch"`
+		Action         string `json:"action"`
+		Draft          bool   `json:"draft"`
+		WorkInProgress bool   `json:"work_in_progress"`
+	} `json:"object_attributes"`
+	Changes struct {
+		Draft          *GitLabBoolChange `json:"draft"`
+		WorkInProgress *GitLabBoolChange `json:"work_in_progress"`
+	} `json:"changes"`
+}
+
+type GitLabBoolChange struct {
+	Previous bool `json:"previous"`
+	Current  bool `json:"current"`
+}
+
+// IntegrationResponse сообщает, какое действие выполнено по входящему событию.
+type IntegrationResponse struct {
+	Result        string `json:"result"`
+	PullRequestID string `json:"pull_request_id,omitempty"`
+}
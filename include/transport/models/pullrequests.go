@@ -6,15 +6,56 @@ type CreatePRRequest struct {
 	PullRequestID   string `json:"pull_request_id"`
 	PullRequestName string `json:"pull_request_name"`
 	AuthorID        string `json:"author_id"`
+	Strategy        string `json:"strategy,omitempty"`
+	Draft           bool   `json:"draft,omitempty"`
+	Repository      string `json:"repository,omitempty"`
+	SourceBranch    string `json:"source_branch,omitempty"`
+	TargetBranch    string `json:"target_branch,omitempty"`
+	Description     string `json:"description,omitempty"`
+	URL             string `json:"url,omitempty"`
+	// ChangedFiles — пути изменённых файлов для выбора владельцев по CODEOWNERS.
+	ChangedFiles []string `json:"changed_files,omitempty"`
+	// Labels — метки PR, по которым подбирается ревьювер с подходящими тегами.
+	Labels []string `json:"labels,omitempty"`
+}
+
+// PRUpdateRequest — отсутствующие поля не изменяются, labels заменяет метки PR целиком.
+type PRUpdateRequest struct {
+	PullRequestID   string    `json:"pull_request_id"`
+	PullRequestName *string   `json:"pull_request_name,omitempty"`
+	Repository      *string   `json:"repository,omitempty"`
+	SourceBranch    *string   `json:"source_branch,omitempty"`
+	TargetBranch    *string   `json:"target_branch,omitempty"`
+	Description     *string   `json:"description,omitempty"`
+	URL             *string   `json:"url,omitempty"`
+	Labels          *[]string `json:"labels,omitempty"`
+}
+
+type PRLabelsRequest struct {
+	PullRequestID string   `json:"pull_request_id"`
+	Labels        []string `json:"labels"`
 }
 
 type MergePRRequest struct {
 	PullRequestID string `json:"pull_request_id"`
+	Force         bool   `json:"force,omitempty"`
 }
 
 type ReassignRequest struct {
 	PullRequestID string `json:"pull_request_id"`
 	OldUserID     string `json:"old_reviewer_id"`
+	Strategy      string `json:"strategy,omitempty"`
+}
+
+type ReviewRequest struct {
+	PullRequestID string `json:"pull_request_id"`
+	ReviewerID    string `json:"reviewer_id"`
+	State         string `json:"state"`
+	Comment       string `json:"comment,omitempty"`
+}
+
+type PRStatusRequest struct {
+	PullRequestID string `json:"pull_request_id"`
 }
 
 type CreatePRResponse struct {
@@ -23,9 +64,44 @@ type CreatePRResponse struct {
 
 type MergePRResponse struct {
 	PullRequest models.PullRequest `json:"pr"`
+	// Merged — true, если PR был смержен этим вызовом, false для повторного merge.
+	Merged bool `json:"merged"`
 }
 
 type ReassignResponse struct {
 	PullRequest models.PullRequest `json:"pr"`
 	ReplacedBy  string             `json:"replaced_by"`
 }
+
+type ReviewResponse struct {
+	PullRequest models.PullRequest `json:"pr"`
+	Review      models.Review      `json:"review"`
+}
+
+type PRStatusResponse struct {
+	PullRequest models.PullRequest `json:"pr"`
+}
+
+type PRUpdateResponse struct {
+	PullRequest models.PullRequest `json:"pr"`
+}
+
+type PRLabelsResponse struct {
+	PullRequest models.PullRequest `json:"pr"`
+}
+
+type PRGetResponse struct {
+	PullRequest models.PullRequest `json:"pr"`
+}
+
+type PRHistoryResponse struct {
+	PullRequestID string                   `json:"pull_request_id"`
+	Events        []models.AssignmentEvent `json:"events"`
+}
+
+type PRListResponse struct {
+	PullRequests []models.PullRequest `json:"pull_requests"`
+	Total        int                  `json:"total"`
+	Limit        int                  `json:"limit"`
+	Offset       int                  `json:"offset"`
+}
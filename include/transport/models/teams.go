@@ -5,3 +5,50 @@ import "github.com/RomanKovalev007/pull_request_service/include/models"
 type TeamCreateResponse struct {
 	Team models.Team `json:"team"`
 }
+
+// TeamSettingsRequest — отсутствующие поля не изменяются, fallback_teams заменяет список целиком.
+type TeamSettingsRequest struct {
+	TeamName          string    `json:"team_name"`
+	ReviewerCount     *int      `json:"reviewer_count,omitempty"`
+	MinReviewers      *int      `json:"min_reviewers,omitempty"`
+	RequiredApprovals *int      `json:"required_approvals,omitempty"`
+	FallbackTeams     *[]string `json:"fallback_teams,omitempty"`
+}
+
+type TeamSettingsResponse struct {
+	Settings models.TeamSettings `json:"settings"`
+}
+
+type TeamCodeOwnersResponse struct {
+	CodeOwners models.TeamCodeOwners `json:"codeowners"`
+}
+
+type TeamResponse struct {
+	Team models.Team `json:"team"`
+}
+
+type TeamListResponse struct {
+	Teams []models.Team `json:"teams"`
+}
+
+type TeamRenameRequest struct {
+	TeamName    string `json:"team_name"`
+	NewTeamName string `json:"new_team_name"`
+}
+
+type TeamDeleteRequest struct {
+	TeamName string `json:"team_name"`
+	// OpenReviews — политика для открытых ревью участников: reassign (по умолчанию), reject или drop
+	OpenReviews string `json:"open_reviews,omitempty"`
+}
+
+type TeamDeleteResponse struct {
+	TeamName             string                 `json:"team_name"`
+	ReleasedMembers      []string               `json:"released_members"`
+	AffectedPullRequests []models.ReviewHandoff `json:"affected_pull_requests"`
+}
+
+type TeamMembersRemoveRequest struct {
+	TeamName string   `json:"team_name"`
+	UserIDs  []string `json:"user_ids"`
+}
@@ -1,6 +1,10 @@
 package transport
 
-import "github.com/RomanKovalev007/pull_request_service/include/models"
+import (
+	"time"
+
+	"github.com/RomanKovalev007/pull_request_service/include/models"
+)
 
 type UserSetActiveRequest struct {
 	UserID   string `json:"user_id"`
@@ -11,7 +15,87 @@ type UserSetActiveResponse struct {
 	User models.User `json:"user"`
 }
 
+type UserSetCapacityRequest struct {
+	UserID string `json:"user_id"`
+	// Capacity — максимум открытых ревью, null снимает ограничение
+	Capacity *int `json:"capacity"`
+}
+
+type UserSetCapacityResponse struct {
+	User models.User `json:"user"`
+}
+
+type UserResponse struct {
+	User models.User `json:"user"`
+}
+
+type UserListResponse struct {
+	Users []models.User `json:"users"`
+}
+
+type UserCreateRequest struct {
+	UserID   string `json:"user_id"`
+	Username string `json:"username"`
+	TeamName string `json:"team_name"`
+	// IsActive по умолчанию true
+	IsActive       *bool    `json:"is_active,omitempty"`
+	ReviewCapacity *int     `json:"review_capacity,omitempty"`
+	Tags           []string `json:"tags,omitempty"`
+}
+
+type UserTagsRequest struct {
+	UserID string   `json:"user_id"`
+	Tags   []string `json:"tags"`
+}
+
+// UserUpdateRequest — отсутствующие поля не изменяются.
+type UserUpdateRequest struct {
+	UserID   string  `json:"user_id"`
+	Username *string `json:"username,omitempty"`
+	TeamName *string `json:"team_name,omitempty"`
+	IsActive *bool   `json:"is_active,omitempty"`
+}
+
+type UserMoveTeamRequest struct {
+	UserID   string `json:"user_id"`
+	TeamName string `json:"team_name"`
+	// Reviews — что делать с открытыми ревью: keep, reassign (по умолчанию) или drop
+	Reviews string `json:"reviews,omitempty"`
+}
+
+type UserUpdateResponse struct {
+	User                 models.User            `json:"user"`
+	AffectedPullRequests []models.ReviewHandoff `json:"affected_pull_requests"`
+}
+
+type UserMoveTeamResponse struct {
+	User                 models.User            `json:"user"`
+	AffectedPullRequests []models.ReviewHandoff `json:"affected_pull_requests"`
+}
+
+type UserPRsRequest struct {
+	UserID string
+	Status string
+	Since  *time.Time
+	Limit  int
+	Cursor string
+}
+
 type UserPRsResponse struct {
 	UserID       string                    `json:"user_id"`
 	PullRequests []models.PullRequestShort `json:"pull_requests"`
+	NextCursor   string                    `json:"next_cursor,omitempty"`
+}
+
+type AvailabilityDeleteRequest struct {
+	ID int64 `json:"id"`
+}
+
+type AvailabilityResponse struct {
+	Availability models.Availability `json:"availability"`
+}
+
+type UserAvailabilityResponse struct {
+	UserID       string                `json:"user_id"`
+	Availability []models.Availability `json:"availability"`
 }
@@ -0,0 +1,29 @@
+package transport
+
+import "github.com/RomanKovalev007/pull_request_service/include/models"
+
+type WebhookCreateRequest struct {
+	URL    string   `json:"url"`
+	Secret string   `json:"secret,omitempty"`
+	Events []string `json:"events,omitempty"`
+}
+
+type WebhookResponse struct {
+	Webhook models.Webhook `json:"webhook"`
+}
+
+type WebhookListResponse struct {
+	Webhooks []models.Webhook `json:"webhooks"`
+}
+
+type WebhookDeleteRequest struct {
+	ID int64 `json:"id"`
+}
+
+type DeadDeliveriesResponse struct {
+	Deliveries []models.WebhookDelivery `json:"deliveries"`
+}
+
+type DeliveryRetryRequest struct {
+	DeliveryID int64 `json:"delivery_id"`
+}
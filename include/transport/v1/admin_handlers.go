@@ -0,0 +1,112 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/RomanKovalev007/pull_request_service/include/models"
+	"github.com/RomanKovalev007/pull_request_service/include/service"
+	transport "github.com/RomanKovalev007/pull_request_service/include/transport/models"
+)
+
+const (
+	maxRosterSize   = 10 << 20
+	maxSnapshotSize = 100 << 20
+)
+
+type AdminService interface {
+	ImportRoster(ctx context.Context, req transport.ImportRequest) (*transport.ImportResponse, error)
+	ExportSnapshot(ctx context.Context) (*models.Snapshot, error)
+	RestoreSnapshot(ctx context.Context, snap models.Snapshot) (*transport.RestoreResponse, error)
+}
+
+type AdminHandler struct {
+	adminService AdminService
+}
+
+func NewAdminHandler(adminService AdminService) *AdminHandler {
+	return &AdminHandler{adminService: adminService}
+}
+
+// ImportRoster принимает оргструктуру в теле запроса. Формат берётся из параметра format
+// или из Content-Type (text/csv — csv, иначе yaml), dry_run=true только строит план.
+func (h *AdminHandler) ImportRoster(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	format := query.Get("format")
+	if format == "" {
+		format = service.RosterYAML
+		if strings.HasPrefix(r.Header.Get("Content-Type"), "text/csv") {
+			format = service.RosterCSV
+		}
+	}
+
+	req := transport.ImportRequest{}
+	if value := query.Get("dry_run"); value != "" {
+		dryRun, err := strconv.ParseBool(value)
+		if err != nil {
+			sendError(w, http.StatusBadRequest, models.INVALID_INPUT, "dry_run must be a boolean")
+			return
+		}
+		req.DryRun = dryRun
+	}
+
+	roster, err := service.ParseRoster(http.MaxBytesReader(w, r.Body, maxRosterSize), format)
+	if err != nil {
+		sendError(w, http.StatusBadRequest, models.INVALID_INPUT, "invalid roster: "+err.Error())
+		return
+	}
+	req.Roster = roster
+
+	resp, err := h.adminService.ImportRoster(r.Context(), req)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		sendError(w, http.StatusInternalServerError, models.INTERNAL_ERROR, "internal server error")
+		return
+	}
+}
+
+func (h *AdminHandler) ExportSnapshot(w http.ResponseWriter, r *http.Request) {
+	snap, err := h.adminService.ExportSnapshot(r.Context())
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Disposition", `attachment; filename="snapshot.json"`)
+	if err := json.NewEncoder(w).Encode(snap); err != nil {
+		sendError(w, http.StatusInternalServerError, models.INTERNAL_ERROR, "internal server error")
+		return
+	}
+}
+
+// RestoreSnapshot загружает выгрузку /admin/export в пустую базу.
+func (h *AdminHandler) RestoreSnapshot(w http.ResponseWriter, r *http.Request) {
+	var snap models.Snapshot
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxSnapshotSize)).Decode(&snap); err != nil {
+		sendError(w, http.StatusBadRequest, models.INVALID_INPUT, "invalid request payload")
+		return
+	}
+
+	resp, err := h.adminService.RestoreSnapshot(r.Context(), snap)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		sendError(w, http.StatusInternalServerError, models.INTERNAL_ERROR, "internal server error")
+		return
+	}
+}
@@ -0,0 +1,88 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/RomanKovalev007/pull_request_service/include/models"
+	transport "github.com/RomanKovalev007/pull_request_service/include/transport/models"
+)
+
+func (h *UserHandler) AddAvailability(w http.ResponseWriter, r *http.Request) {
+	var req models.Availability
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		sendError(w, http.StatusBadRequest, models.INVALID_INPUT, "invalid request payload")
+		return
+	}
+
+	availability, err := h.userService.AddAvailability(r.Context(), req)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(availability); err != nil {
+		sendError(w, http.StatusInternalServerError, models.INTERNAL_ERROR, "internal server error")
+		return
+	}
+}
+
+func (h *UserHandler) GetUserAvailability(w http.ResponseWriter, r *http.Request) {
+	userID := r.URL.Query().Get("user_id")
+	if userID == "" {
+		sendError(w, http.StatusBadRequest, models.INVALID_INPUT, "invalid request payload")
+		return
+	}
+
+	periods, err := h.userService.GetUserAvailability(r.Context(), userID)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(periods); err != nil {
+		sendError(w, http.StatusInternalServerError, models.INTERNAL_ERROR, "internal server error")
+		return
+	}
+}
+
+func (h *UserHandler) UpdateAvailability(w http.ResponseWriter, r *http.Request) {
+	var req models.Availability
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		sendError(w, http.StatusBadRequest, models.INVALID_INPUT, "invalid request payload")
+		return
+	}
+
+	availability, err := h.userService.UpdateAvailability(r.Context(), req)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(availability); err != nil {
+		sendError(w, http.StatusInternalServerError, models.INTERNAL_ERROR, "internal server error")
+		return
+	}
+}
+
+func (h *UserHandler) DeleteAvailability(w http.ResponseWriter, r *http.Request) {
+	var req transport.AvailabilityDeleteRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		sendError(w, http.StatusBadRequest, models.INVALID_INPUT, "invalid request payload")
+		return
+	}
+
+	if err := h.userService.DeleteAvailability(r.Context(), req); err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -3,6 +3,9 @@ package v1
 import (
 	"encoding/json"
 	"net/http"
+	"net/url"
+	"strconv"
+	"time"
 
 	"github.com/RomanKovalev007/pull_request_service/include/models"
 	"github.com/RomanKovalev007/pull_request_service/include/repository"
@@ -27,16 +30,30 @@ func handleServiceError(w http.ResponseWriter, err error) {
 		switch serviceErr.Code {
 		case repository.ErrTeamExists.Error():
 			sendError(w, http.StatusBadRequest, models.TEAMEXISTS, serviceErr.Message)
+		case repository.ErrUserExists.Error():
+			sendError(w, http.StatusConflict, models.USEREXISTS, serviceErr.Message)
 		case repository.ErrPRExists.Error():
 			sendError(w, http.StatusConflict, models.PREXISTS, serviceErr.Message)
 		case repository.ErrPRMerged.Error():
 			sendError(w, http.StatusConflict, models.PRMERGED, serviceErr.Message)
 		case repository.ErrNotAssigned.Error():
 			sendError(w, http.StatusConflict, models.NOTASSIGNED, serviceErr.Message)
+		case repository.ErrMergeBlocked.Error():
+			sendError(w, http.StatusConflict, models.MERGEBLOCKED, serviceErr.Message)
+		case repository.ErrInvalidTransition.Error():
+			sendError(w, http.StatusConflict, models.INVALIDSTATUS, serviceErr.Message)
+		case repository.ErrTeamHasOpenReviews.Error():
+			sendError(w, http.StatusConflict, models.TEAMHASREVIEWS, serviceErr.Message)
+		case repository.ErrTeamReviewsOrphaned.Error():
+			sendError(w, http.StatusConflict, models.TEAMORPHANS, serviceErr.Message)
+		case repository.ErrNotEmpty.Error():
+			sendError(w, http.StatusConflict, models.NOTEMPTY, serviceErr.Message)
 		case repository.ErrNoCandidate.Error():
 			sendError(w, http.StatusConflict, models.NOCANDIDATE, serviceErr.Message)
 		case repository.ErrNotFound.Error():
 			sendError(w, http.StatusNotFound, models.NOTFOUND, serviceErr.Message)
+		case service.ErrInvalidSignature.Error():
+			sendError(w, http.StatusUnauthorized, models.INVALIDSIGN, serviceErr.Message)
 		case service.ErrInvalidInput.Error():
 			sendError(w, http.StatusBadRequest, models.INVALID_INPUT, serviceErr.Message)
 		default:
@@ -46,3 +63,37 @@ func handleServiceError(w http.ResponseWriter, err error) {
 		sendError(w, http.StatusInternalServerError, models.INTERNAL_ERROR, "internal server error")
 	}
 }
+
+// queryInt читает необязательный целочисленный параметр запроса, отсутствующий параметр — 0.
+func queryInt(query url.Values, key string) (int, error) {
+	value := query.Get(key)
+	if value == "" {
+		return 0, nil
+	}
+	return strconv.Atoi(value)
+}
+
+// queryTime читает необязательный параметр запроса в формате RFC 3339.
+func queryTime(query url.Values, key string) (*time.Time, error) {
+	value := query.Get(key)
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// actorHeader задаёт автора изменения для журнала назначений.
+const actorHeader = "X-Actor"
+
+func withActor(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if actor := r.Header.Get(actorHeader); actor != "" {
+			r = r.WithContext(models.WithActor(r.Context(), actor))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
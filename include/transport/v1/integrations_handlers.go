@@ -0,0 +1,80 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/RomanKovalev007/pull_request_service/include/models"
+	transport "github.com/RomanKovalev007/pull_request_service/include/transport/models"
+)
+
+const maxWebhookPayloadSize = 25 << 20
+
+type IntegrationService interface {
+	VerifyGitHubSignature(body []byte, signature string) error
+	HandleGitHubEvent(ctx context.Context, eventType string, body []byte) (*transport.IntegrationResponse, error)
+	VerifyGitLabToken(token string) error
+	HandleGitLabEvent(ctx context.Context, eventType string, body []byte) (*transport.IntegrationResponse, error)
+}
+
+type IntegrationHandler struct {
+	integrationService IntegrationService
+}
+
+func NewIntegrationHandler(integrationService IntegrationService) *IntegrationHandler {
+	return &IntegrationHandler{integrationService: integrationService}
+}
+
+// GitHubWebhook принимает события GitHub. Подпись проверяется по сырому телу запроса.
+func (h *IntegrationHandler) GitHubWebhook(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookPayloadSize))
+	if err != nil {
+		sendError(w, http.StatusBadRequest, models.INVALID_INPUT, "invalid request payload")
+		return
+	}
+
+	if err := h.integrationService.VerifyGitHubSignature(body, r.Header.Get("X-Hub-Signature-256")); err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	resp, err := h.integrationService.HandleGitHubEvent(r.Context(), r.Header.Get("X-GitHub-Event"), body)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		sendError(w, http.StatusInternalServerError, models.INTERNAL_ERROR, "internal server error")
+		return
+	}
+}
+
+// GitLabWebhook принимает события GitLab, подлинность проверяется по X-Gitlab-Token.
+func (h *IntegrationHandler) GitLabWebhook(w http.ResponseWriter, r *http.Request) {
+	if err := h.integrationService.VerifyGitLabToken(r.Header.Get("X-Gitlab-Token")); err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookPayloadSize))
+	if err != nil {
+		sendError(w, http.StatusBadRequest, models.INVALID_INPUT, "invalid request payload")
+		return
+	}
+
+	resp, err := h.integrationService.HandleGitLabEvent(r.Context(), r.Header.Get("X-Gitlab-Event"), body)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		sendError(w, http.StatusInternalServerError, models.INTERNAL_ERROR, "internal server error")
+		return
+	}
+}
@@ -0,0 +1,68 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/RomanKovalev007/pull_request_service/include/models"
+	transport "github.com/RomanKovalev007/pull_request_service/include/transport/models"
+)
+
+func (h *UserHandler) AddUserTags(w http.ResponseWriter, r *http.Request) {
+	h.changeTags(w, r, h.userService.AddUserTags)
+}
+
+func (h *UserHandler) RemoveUserTags(w http.ResponseWriter, r *http.Request) {
+	h.changeTags(w, r, h.userService.RemoveUserTags)
+}
+
+func (h *UserHandler) changeTags(w http.ResponseWriter, r *http.Request, change func(context.Context, transport.UserTagsRequest) (*transport.UserResponse, error)) {
+	var req transport.UserTagsRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		sendError(w, http.StatusBadRequest, models.INVALID_INPUT, "invalid request payload")
+		return
+	}
+
+	user, err := change(r.Context(), req)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(user); err != nil {
+		sendError(w, http.StatusInternalServerError, models.INTERNAL_ERROR, "internal server error")
+		return
+	}
+}
+
+func (h *PRHandler) AddPullRequestLabels(w http.ResponseWriter, r *http.Request) {
+	h.changeLabels(w, r, h.prService.AddPullRequestLabels)
+}
+
+func (h *PRHandler) RemovePullRequestLabels(w http.ResponseWriter, r *http.Request) {
+	h.changeLabels(w, r, h.prService.RemovePullRequestLabels)
+}
+
+func (h *PRHandler) changeLabels(w http.ResponseWriter, r *http.Request, change func(context.Context, transport.PRLabelsRequest) (*transport.PRLabelsResponse, error)) {
+	var req transport.PRLabelsRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		sendError(w, http.StatusBadRequest, models.INVALID_INPUT, "invalid request payload")
+		return
+	}
+
+	pr, err := change(r.Context(), req)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(pr); err != nil {
+		sendError(w, http.StatusInternalServerError, models.INTERNAL_ERROR, "internal server error")
+		return
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"net/http"
+	"time"
 
 	"github.com/RomanKovalev007/pull_request_service/include/models"
 	transport "github.com/RomanKovalev007/pull_request_service/include/transport/models"
@@ -13,6 +14,20 @@ type PRService interface {
 	CreatePullRequest(ctx context.Context, req transport.CreatePRRequest) (*transport.CreatePRResponse, error)
 	MergePullRequest(ctx context.Context, req transport.MergePRRequest) (*transport.MergePRResponse, error)
 	ReassignReviewer(ctx context.Context, req transport.ReassignRequest) (*transport.ReassignResponse, error)
+	SubmitReview(ctx context.Context, req transport.ReviewRequest) (*transport.ReviewResponse, error)
+
+	MarkReadyForReview(ctx context.Context, req transport.PRStatusRequest) (*transport.PRStatusResponse, error)
+	ClosePullRequest(ctx context.Context, req transport.PRStatusRequest) (*transport.PRStatusResponse, error)
+	ReopenPullRequest(ctx context.Context, req transport.PRStatusRequest) (*transport.PRStatusResponse, error)
+	ConvertToDraft(ctx context.Context, req transport.PRStatusRequest) (*transport.PRStatusResponse, error)
+
+	UpdatePullRequest(ctx context.Context, req transport.PRUpdateRequest) (*transport.PRUpdateResponse, error)
+	AddPullRequestLabels(ctx context.Context, req transport.PRLabelsRequest) (*transport.PRLabelsResponse, error)
+	RemovePullRequestLabels(ctx context.Context, req transport.PRLabelsRequest) (*transport.PRLabelsResponse, error)
+
+	GetPullRequest(ctx context.Context, prID string) (*transport.PRGetResponse, error)
+	ListPullRequests(ctx context.Context, filter models.PullRequestFilter) (*transport.PRListResponse, error)
+	GetAssignmentHistory(ctx context.Context, prID string) (*transport.PRHistoryResponse, error)
 }
 
 type PRHandler struct {
@@ -86,3 +101,173 @@ func (h *PRHandler) ReassignReviewer(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 }
+
+func (h *PRHandler) SubmitReview(w http.ResponseWriter, r *http.Request) {
+	var req transport.ReviewRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		sendError(w, http.StatusBadRequest, models.INVALID_INPUT, "invalid request payload")
+		return
+	}
+
+	review, err := h.prService.SubmitReview(r.Context(), req)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(review); err != nil {
+		sendError(w, http.StatusInternalServerError, models.INTERNAL_ERROR, "internal server error")
+		return
+	}
+}
+
+func (h *PRHandler) UpdatePullRequest(w http.ResponseWriter, r *http.Request) {
+	var req transport.PRUpdateRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		sendError(w, http.StatusBadRequest, models.INVALID_INPUT, "invalid request payload")
+		return
+	}
+
+	pr, err := h.prService.UpdatePullRequest(r.Context(), req)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(pr); err != nil {
+		sendError(w, http.StatusInternalServerError, models.INTERNAL_ERROR, "internal server error")
+		return
+	}
+}
+
+func (h *PRHandler) MarkReadyForReview(w http.ResponseWriter, r *http.Request) {
+	h.changeStatus(w, r, h.prService.MarkReadyForReview)
+}
+
+func (h *PRHandler) ClosePullRequest(w http.ResponseWriter, r *http.Request) {
+	h.changeStatus(w, r, h.prService.ClosePullRequest)
+}
+
+func (h *PRHandler) ReopenPullRequest(w http.ResponseWriter, r *http.Request) {
+	h.changeStatus(w, r, h.prService.ReopenPullRequest)
+}
+
+func (h *PRHandler) ConvertToDraft(w http.ResponseWriter, r *http.Request) {
+	h.changeStatus(w, r, h.prService.ConvertToDraft)
+}
+
+func (h *PRHandler) changeStatus(w http.ResponseWriter, r *http.Request, change func(context.Context, transport.PRStatusRequest) (*transport.PRStatusResponse, error)) {
+	var req transport.PRStatusRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		sendError(w, http.StatusBadRequest, models.INVALID_INPUT, "invalid request payload")
+		return
+	}
+
+	pr, err := change(r.Context(), req)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(pr); err != nil {
+		sendError(w, http.StatusInternalServerError, models.INTERNAL_ERROR, "internal server error")
+		return
+	}
+}
+
+func (h *PRHandler) GetPullRequest(w http.ResponseWriter, r *http.Request) {
+	prID := r.URL.Query().Get("pull_request_id")
+	if prID == "" {
+		sendError(w, http.StatusBadRequest, models.INVALID_INPUT, "invalid request payload")
+		return
+	}
+
+	pr, err := h.prService.GetPullRequest(r.Context(), prID)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(pr); err != nil {
+		sendError(w, http.StatusInternalServerError, models.INTERNAL_ERROR, "internal server error")
+		return
+	}
+}
+
+// GetAssignmentHistory возвращает журнал изменений состава ревьюверов PR.
+func (h *PRHandler) GetAssignmentHistory(w http.ResponseWriter, r *http.Request) {
+	prID := r.URL.Query().Get("pull_request_id")
+	if prID == "" {
+		sendError(w, http.StatusBadRequest, models.INVALID_INPUT, "invalid request payload")
+		return
+	}
+
+	resp, err := h.prService.GetAssignmentHistory(r.Context(), prID)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		sendError(w, http.StatusInternalServerError, models.INTERNAL_ERROR, "internal server error")
+		return
+	}
+}
+
+func (h *PRHandler) ListPullRequests(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := models.PullRequestFilter{
+		Status:       query.Get("status"),
+		AuthorID:     query.Get("author_id"),
+		ReviewerID:   query.Get("reviewer_id"),
+		TeamName:     query.Get("team_name"),
+		Repository:   query.Get("repository"),
+		SourceBranch: query.Get("source_branch"),
+		TargetBranch: query.Get("target_branch"),
+		Labels:       query["label"],
+		Search:       query.Get("q"),
+	}
+
+	var err error
+	if filter.Limit, err = queryInt(query, "limit"); err != nil {
+		sendError(w, http.StatusBadRequest, models.INVALID_INPUT, "limit must be an integer")
+		return
+	}
+	if filter.Offset, err = queryInt(query, "offset"); err != nil {
+		sendError(w, http.StatusBadRequest, models.INVALID_INPUT, "offset must be an integer")
+		return
+	}
+
+	for key, dst := range map[string]**time.Time{
+		"created_from": &filter.CreatedFrom,
+		"created_to":   &filter.CreatedTo,
+		"merged_from":  &filter.MergedFrom,
+		"merged_to":    &filter.MergedTo,
+	} {
+		if *dst, err = queryTime(query, key); err != nil {
+			sendError(w, http.StatusBadRequest, models.INVALID_INPUT, key+" must be an RFC 3339 timestamp")
+			return
+		}
+	}
+
+	prs, err := h.prService.ListPullRequests(r.Context(), filter)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(prs); err != nil {
+		sendError(w, http.StatusInternalServerError, models.INTERNAL_ERROR, "internal server error")
+		return
+	}
+}
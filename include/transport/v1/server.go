@@ -2,7 +2,9 @@ package v1
 
 import (
 	"context"
+	"fmt"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/RomanKovalev007/pull_request_service/include/models"
@@ -14,13 +16,60 @@ import (
 type Service interface {
 	CreateTeam(ctx context.Context, req models.Team) (*transport.TeamCreateResponse, error)
 	GetTeam(ctx context.Context, teamName string) (*models.Team, error)
+	GetTeamSettings(ctx context.Context, teamName string) (*models.TeamSettings, error)
+	SetTeamSettings(ctx context.Context, req transport.TeamSettingsRequest) (*transport.TeamSettingsResponse, error)
+	GetTeamCodeOwners(ctx context.Context, teamName string) (*models.TeamCodeOwners, error)
+	SetTeamCodeOwners(ctx context.Context, req models.TeamCodeOwners) (*transport.TeamCodeOwnersResponse, error)
+	ListTeams(ctx context.Context) (*transport.TeamListResponse, error)
+	RenameTeam(ctx context.Context, req transport.TeamRenameRequest) (*transport.TeamResponse, error)
+	DeleteTeam(ctx context.Context, req transport.TeamDeleteRequest) (*transport.TeamDeleteResponse, error)
+	AddTeamMembers(ctx context.Context, req models.Team) (*transport.TeamResponse, error)
+	RemoveTeamMembers(ctx context.Context, req transport.TeamMembersRemoveRequest) (*transport.TeamResponse, error)
 
 	SetUserIsActive(ctx context.Context, req transport.UserSetActiveRequest) (*transport.UserSetActiveResponse, error)
-	GetUserPullRequests(ctx context.Context, userID string) (*transport.UserPRsResponse, error)
+	GetUserPullRequests(ctx context.Context, req transport.UserPRsRequest) (*transport.UserPRsResponse, error)
+	SetUserCapacity(ctx context.Context, req transport.UserSetCapacityRequest) (*transport.UserSetCapacityResponse, error)
+	MoveUserTeam(ctx context.Context, req transport.UserMoveTeamRequest) (*transport.UserMoveTeamResponse, error)
+	GetUser(ctx context.Context, userID string) (*transport.UserResponse, error)
+	ListUsers(ctx context.Context, filter models.UserFilter) (*transport.UserListResponse, error)
+	CreateUser(ctx context.Context, req transport.UserCreateRequest) (*transport.UserResponse, error)
+	UpdateUser(ctx context.Context, req transport.UserUpdateRequest) (*transport.UserUpdateResponse, error)
+	AddUserTags(ctx context.Context, req transport.UserTagsRequest) (*transport.UserResponse, error)
+	RemoveUserTags(ctx context.Context, req transport.UserTagsRequest) (*transport.UserResponse, error)
+	AddAvailability(ctx context.Context, req models.Availability) (*transport.AvailabilityResponse, error)
+	GetUserAvailability(ctx context.Context, userID string) (*transport.UserAvailabilityResponse, error)
+	UpdateAvailability(ctx context.Context, req models.Availability) (*transport.AvailabilityResponse, error)
+	DeleteAvailability(ctx context.Context, req transport.AvailabilityDeleteRequest) error
 
 	CreatePullRequest(ctx context.Context, req transport.CreatePRRequest) (*transport.CreatePRResponse, error)
 	MergePullRequest(ctx context.Context, req transport.MergePRRequest) (*transport.MergePRResponse, error)
 	ReassignReviewer(ctx context.Context, req transport.ReassignRequest) (*transport.ReassignResponse, error)
+	SubmitReview(ctx context.Context, req transport.ReviewRequest) (*transport.ReviewResponse, error)
+	MarkReadyForReview(ctx context.Context, req transport.PRStatusRequest) (*transport.PRStatusResponse, error)
+	ClosePullRequest(ctx context.Context, req transport.PRStatusRequest) (*transport.PRStatusResponse, error)
+	ReopenPullRequest(ctx context.Context, req transport.PRStatusRequest) (*transport.PRStatusResponse, error)
+	ConvertToDraft(ctx context.Context, req transport.PRStatusRequest) (*transport.PRStatusResponse, error)
+	UpdatePullRequest(ctx context.Context, req transport.PRUpdateRequest) (*transport.PRUpdateResponse, error)
+	AddPullRequestLabels(ctx context.Context, req transport.PRLabelsRequest) (*transport.PRLabelsResponse, error)
+	RemovePullRequestLabels(ctx context.Context, req transport.PRLabelsRequest) (*transport.PRLabelsResponse, error)
+	GetPullRequest(ctx context.Context, prID string) (*transport.PRGetResponse, error)
+	ListPullRequests(ctx context.Context, filter models.PullRequestFilter) (*transport.PRListResponse, error)
+	GetAssignmentHistory(ctx context.Context, prID string) (*transport.PRHistoryResponse, error)
+
+	ImportRoster(ctx context.Context, req transport.ImportRequest) (*transport.ImportResponse, error)
+	ExportSnapshot(ctx context.Context) (*models.Snapshot, error)
+	RestoreSnapshot(ctx context.Context, snap models.Snapshot) (*transport.RestoreResponse, error)
+
+	CreateWebhook(ctx context.Context, req transport.WebhookCreateRequest) (*transport.WebhookResponse, error)
+	ListWebhooks(ctx context.Context) (*transport.WebhookListResponse, error)
+	DeleteWebhook(ctx context.Context, req transport.WebhookDeleteRequest) error
+	ListDeadDeliveries(ctx context.Context, webhookID int64) (*transport.DeadDeliveriesResponse, error)
+	RetryDelivery(ctx context.Context, req transport.DeliveryRetryRequest) error
+
+	VerifyGitHubSignature(body []byte, signature string) error
+	HandleGitHubEvent(ctx context.Context, eventType string, body []byte) (*transport.IntegrationResponse, error)
+	VerifyGitLabToken(token string) error
+	HandleGitLabEvent(ctx context.Context, eventType string, body []byte) (*transport.IntegrationResponse, error)
 }
 
 var (
@@ -32,20 +81,39 @@ type Server struct {
 	srv  *http.Server
 	repo *repository.Repo
 	mux  *http.ServeMux
+	cfg  service.ReviewerConfig
 
-	teamService  *service.TeamService
-	userService  *service.UserService
-	prService    *service.PrService
-	statsService *service.StatsService
+	teamService        *service.TeamService
+	userService        *service.UserService
+	prService          *service.PrService
+	statsService       *service.StatsService
+	adminService       *service.AdminService
+	webhookService     *service.WebhookService
+	integrationService *service.IntegrationService
+	reviewerSync       *service.ReviewerSyncService
 
 	// Добавляем поля для обработчиков
-	teamHandler  *TeamHandler
-	userHandler  *UserHandler
-	prHandler    *PRHandler
-	statsHandler *StatsHandler
+	teamHandler        *TeamHandler
+	userHandler        *UserHandler
+	prHandler          *PRHandler
+	statsHandler       *StatsHandler
+	adminHandler       *AdminHandler
+	webhookHandler     *WebhookHandler
+	integrationHandler *IntegrationHandler
 }
 
-func NewServer(port string, db *repository.Repo) *Server {
+func NewServer(port string, db *repository.Repo, cfg service.ReviewerConfig) (*Server, error) {
+	selector, err := service.NewReviewerSelector(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create reviewer selector: %w", err)
+	}
+
+	// выгрузка назначений в GitHub включается токеном API
+	var reviewerSync *service.ReviewerSyncService
+	if cfg.GitHubToken != "" {
+		reviewerSync = service.NewReviewerSyncService(db.PrRepository, service.NewGitHubClient(cfg), cfg)
+	}
+
 	mux := http.NewServeMux()
 
 	srv := http.Server{
@@ -56,21 +124,29 @@ func NewServer(port string, db *repository.Repo) *Server {
 	}
 
 	server := &Server{
-		srv:          &srv,
-		repo:         db,
-		mux:          mux,
-		teamService:  service.NewTeamService(db.TeamRepository),
-		userService:  service.NewUserService(db.UserRepository),
-		prService:    service.NewPrService(db.PrRepository),
-		statsService: service.NewStatsService(db.StatsRepository),
+		srv:            &srv,
+		repo:           db,
+		mux:            mux,
+		cfg:            cfg,
+		teamService:    service.NewTeamService(db.TeamRepository, selector),
+		userService:    service.NewUserService(db.UserRepository, selector),
+		prService:      service.NewPrService(db.PrRepository, selector, reviewerSync, cfg),
+		statsService:   service.NewStatsService(db.StatsRepository),
+		adminService:   service.NewAdminService(db.AdminRepository, selector),
+		webhookService: service.NewWebhookService(db.WebhookRepository, cfg),
+		reviewerSync:   reviewerSync,
 	}
+	server.integrationService = service.NewIntegrationService(server.prService, cfg)
 
 	server.teamHandler = NewTeamHandler(server.teamService)
 	server.userHandler = NewUserHandler(server.userService)
 	server.prHandler = NewPRHandler(server.prService)
 	server.statsHandler = NewStatsHandler(server.statsService)
+	server.adminHandler = NewAdminHandler(server.adminService)
+	server.webhookHandler = NewWebhookHandler(server.webhookService)
+	server.integrationHandler = NewIntegrationHandler(server.integrationService)
 
-	return server
+	return server, nil
 }
 
 func (s *Server) Start() error {
@@ -81,8 +157,33 @@ func (s *Server) Stop(ctx context.Context) error {
 	return s.srv.Shutdown(ctx)
 }
 
+// RunWorkers запускает фоновые задачи сервиса и блокируется до отмены ctx.
+func (s *Server) RunWorkers(ctx context.Context) {
+	var wg sync.WaitGroup
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		s.userService.RunAvailabilityWorker(ctx, s.cfg.AvailabilityCheckInterval)
+	}()
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		s.webhookService.RunDispatcher(ctx, s.cfg.WebhookDispatchInterval)
+	}()
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		s.reviewerSync.RunWorker(ctx, s.cfg.CodeHostSyncInterval)
+	}()
+
+	wg.Wait()
+}
+
 func (s *Server) GetRouter() http.Handler {
-	return s.mux
+	return s.srv.Handler
 }
 
 func (s *Server) RegisterHandlers() error {
@@ -109,6 +210,68 @@ func (s *Server) RegisterHandlers() error {
 		s.teamHandler.GetTeam(w, r)
 	})
 
+	s.mux.HandleFunc("/team/list", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.teamHandler.ListTeams(w, r)
+	})
+
+	s.mux.HandleFunc("/team/rename", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.teamHandler.RenameTeam(w, r)
+	})
+
+	s.mux.HandleFunc("/team/delete", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.teamHandler.DeleteTeam(w, r)
+	})
+
+	s.mux.HandleFunc("/team/members/add", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.teamHandler.AddTeamMembers(w, r)
+	})
+
+	s.mux.HandleFunc("/team/members/remove", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.teamHandler.RemoveTeamMembers(w, r)
+	})
+
+	s.mux.HandleFunc("/team/settings", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			s.teamHandler.GetTeamSettings(w, r)
+		case http.MethodPost:
+			s.teamHandler.SetTeamSettings(w, r)
+		default:
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	s.mux.HandleFunc("/team/codeowners", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			s.teamHandler.GetTeamCodeOwners(w, r)
+		case http.MethodPost:
+			s.teamHandler.SetTeamCodeOwners(w, r)
+		default:
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
 	s.mux.HandleFunc("/users/setIsActive", func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodPost {
 			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
@@ -117,6 +280,102 @@ func (s *Server) RegisterHandlers() error {
 		s.userHandler.SetUserIsActive(w, r)
 	})
 
+	s.mux.HandleFunc("/users/get", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.userHandler.GetUser(w, r)
+	})
+
+	s.mux.HandleFunc("/users/list", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.userHandler.ListUsers(w, r)
+	})
+
+	s.mux.HandleFunc("/users/create", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.userHandler.CreateUser(w, r)
+	})
+
+	s.mux.HandleFunc("/users/update", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.userHandler.UpdateUser(w, r)
+	})
+
+	s.mux.HandleFunc("/users/tags/add", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.userHandler.AddUserTags(w, r)
+	})
+
+	s.mux.HandleFunc("/users/tags/remove", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.userHandler.RemoveUserTags(w, r)
+	})
+
+	s.mux.HandleFunc("/users/setCapacity", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.userHandler.SetUserCapacity(w, r)
+	})
+
+	s.mux.HandleFunc("/users/moveTeam", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.userHandler.MoveUserTeam(w, r)
+	})
+
+	s.mux.HandleFunc("/users/availability/add", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.userHandler.AddAvailability(w, r)
+	})
+
+	s.mux.HandleFunc("/users/availability/list", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.userHandler.GetUserAvailability(w, r)
+	})
+
+	s.mux.HandleFunc("/users/availability/update", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.userHandler.UpdateAvailability(w, r)
+	})
+
+	s.mux.HandleFunc("/users/availability/delete", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.userHandler.DeleteAvailability(w, r)
+	})
+
 	s.mux.HandleFunc("/users/getReview", func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodGet {
 			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
@@ -149,6 +408,174 @@ func (s *Server) RegisterHandlers() error {
 		s.prHandler.ReassignReviewer(w, r)
 	})
 
-	s.srv.Handler = s.mux
+	s.mux.HandleFunc("/pullRequest/review", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.prHandler.SubmitReview(w, r)
+	})
+
+	s.mux.HandleFunc("/pullRequest/ready", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.prHandler.MarkReadyForReview(w, r)
+	})
+
+	s.mux.HandleFunc("/pullRequest/draft", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.prHandler.ConvertToDraft(w, r)
+	})
+
+	s.mux.HandleFunc("/pullRequest/update", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.prHandler.UpdatePullRequest(w, r)
+	})
+
+	s.mux.HandleFunc("/pullRequest/labels/add", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.prHandler.AddPullRequestLabels(w, r)
+	})
+
+	s.mux.HandleFunc("/pullRequest/labels/remove", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.prHandler.RemovePullRequestLabels(w, r)
+	})
+
+	s.mux.HandleFunc("/pullRequest/close", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.prHandler.ClosePullRequest(w, r)
+	})
+
+	s.mux.HandleFunc("/pullRequest/reopen", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.prHandler.ReopenPullRequest(w, r)
+	})
+
+	s.mux.HandleFunc("/pullRequest/get", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.prHandler.GetPullRequest(w, r)
+	})
+
+	s.mux.HandleFunc("/pullRequest/list", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.prHandler.ListPullRequests(w, r)
+	})
+
+	s.mux.HandleFunc("/pullRequest/history", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.prHandler.GetAssignmentHistory(w, r)
+	})
+
+	s.mux.HandleFunc("/admin/import", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.adminHandler.ImportRoster(w, r)
+	})
+
+	s.mux.HandleFunc("/admin/export", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.adminHandler.ExportSnapshot(w, r)
+	})
+
+	s.mux.HandleFunc("/admin/restore", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.adminHandler.RestoreSnapshot(w, r)
+	})
+
+	s.mux.HandleFunc("/webhooks/create", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.webhookHandler.CreateWebhook(w, r)
+	})
+
+	s.mux.HandleFunc("/webhooks/list", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.webhookHandler.ListWebhooks(w, r)
+	})
+
+	s.mux.HandleFunc("/webhooks/delete", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.webhookHandler.DeleteWebhook(w, r)
+	})
+
+	s.mux.HandleFunc("/webhooks/deadLetters", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.webhookHandler.ListDeadDeliveries(w, r)
+	})
+
+	s.mux.HandleFunc("/webhooks/retry", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.webhookHandler.RetryDelivery(w, r)
+	})
+
+	s.mux.HandleFunc("/integrations/github/webhook", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.integrationHandler.GitHubWebhook(w, r)
+	})
+
+	s.mux.HandleFunc("/integrations/gitlab/webhook", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.integrationHandler.GitLabWebhook(w, r)
+	})
+
+	s.srv.Handler = withActor(s.mux)
 	return nil
 }
@@ -12,6 +12,16 @@ import (
 type TeamService interface {
 	CreateTeam(ctx context.Context, req models.Team) (*transport.TeamCreateResponse, error)
 	GetTeam(ctx context.Context, teamName string) (*models.Team, error)
+	GetTeamSettings(ctx context.Context, teamName string) (*models.TeamSettings, error)
+	SetTeamSettings(ctx context.Context, req transport.TeamSettingsRequest) (*transport.TeamSettingsResponse, error)
+	GetTeamCodeOwners(ctx context.Context, teamName string) (*models.TeamCodeOwners, error)
+	SetTeamCodeOwners(ctx context.Context, req models.TeamCodeOwners) (*transport.TeamCodeOwnersResponse, error)
+
+	ListTeams(ctx context.Context) (*transport.TeamListResponse, error)
+	RenameTeam(ctx context.Context, req transport.TeamRenameRequest) (*transport.TeamResponse, error)
+	DeleteTeam(ctx context.Context, req transport.TeamDeleteRequest) (*transport.TeamDeleteResponse, error)
+	AddTeamMembers(ctx context.Context, req models.Team) (*transport.TeamResponse, error)
+	RemoveTeamMembers(ctx context.Context, req transport.TeamMembersRemoveRequest) (*transport.TeamResponse, error)
 }
 
 type TeamHandler struct {
@@ -63,3 +73,183 @@ func (h *TeamHandler) GetTeam(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 }
+
+func (h *TeamHandler) GetTeamSettings(w http.ResponseWriter, r *http.Request) {
+	teamName := r.URL.Query().Get("team_name")
+	if teamName == "" {
+		sendError(w, http.StatusBadRequest, models.INVALID_INPUT, "invalid request payload")
+		return
+	}
+
+	settings, err := h.teamService.GetTeamSettings(r.Context(), teamName)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(settings); err != nil {
+		sendError(w, http.StatusInternalServerError, models.INTERNAL_ERROR, "internal server error")
+		return
+	}
+}
+
+func (h *TeamHandler) SetTeamSettings(w http.ResponseWriter, r *http.Request) {
+	var settings transport.TeamSettingsRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&settings); err != nil {
+		sendError(w, http.StatusBadRequest, models.INVALID_INPUT, "invalid request payload")
+		return
+	}
+
+	result, err := h.teamService.SetTeamSettings(r.Context(), settings)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		sendError(w, http.StatusInternalServerError, models.INTERNAL_ERROR, "internal server error")
+		return
+	}
+}
+
+func (h *TeamHandler) GetTeamCodeOwners(w http.ResponseWriter, r *http.Request) {
+	teamName := r.URL.Query().Get("team_name")
+	if teamName == "" {
+		sendError(w, http.StatusBadRequest, models.INVALID_INPUT, "invalid request payload")
+		return
+	}
+
+	owners, err := h.teamService.GetTeamCodeOwners(r.Context(), teamName)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(owners); err != nil {
+		sendError(w, http.StatusInternalServerError, models.INTERNAL_ERROR, "internal server error")
+		return
+	}
+}
+
+func (h *TeamHandler) SetTeamCodeOwners(w http.ResponseWriter, r *http.Request) {
+	var owners models.TeamCodeOwners
+
+	if err := json.NewDecoder(r.Body).Decode(&owners); err != nil {
+		sendError(w, http.StatusBadRequest, models.INVALID_INPUT, "invalid request payload")
+		return
+	}
+
+	result, err := h.teamService.SetTeamCodeOwners(r.Context(), owners)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		sendError(w, http.StatusInternalServerError, models.INTERNAL_ERROR, "internal server error")
+		return
+	}
+}
+
+func (h *TeamHandler) ListTeams(w http.ResponseWriter, r *http.Request) {
+	teams, err := h.teamService.ListTeams(r.Context())
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(teams); err != nil {
+		sendError(w, http.StatusInternalServerError, models.INTERNAL_ERROR, "internal server error")
+		return
+	}
+}
+
+func (h *TeamHandler) RenameTeam(w http.ResponseWriter, r *http.Request) {
+	var req transport.TeamRenameRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		sendError(w, http.StatusBadRequest, models.INVALID_INPUT, "invalid request payload")
+		return
+	}
+
+	resp, err := h.teamService.RenameTeam(r.Context(), req)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		sendError(w, http.StatusInternalServerError, models.INTERNAL_ERROR, "internal server error")
+		return
+	}
+}
+
+func (h *TeamHandler) DeleteTeam(w http.ResponseWriter, r *http.Request) {
+	var req transport.TeamDeleteRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		sendError(w, http.StatusBadRequest, models.INVALID_INPUT, "invalid request payload")
+		return
+	}
+
+	resp, err := h.teamService.DeleteTeam(r.Context(), req)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		sendError(w, http.StatusInternalServerError, models.INTERNAL_ERROR, "internal server error")
+		return
+	}
+}
+
+func (h *TeamHandler) AddTeamMembers(w http.ResponseWriter, r *http.Request) {
+	var req models.Team
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		sendError(w, http.StatusBadRequest, models.INVALID_INPUT, "invalid request payload")
+		return
+	}
+
+	resp, err := h.teamService.AddTeamMembers(r.Context(), req)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		sendError(w, http.StatusInternalServerError, models.INTERNAL_ERROR, "internal server error")
+		return
+	}
+}
+
+func (h *TeamHandler) RemoveTeamMembers(w http.ResponseWriter, r *http.Request) {
+	var req transport.TeamMembersRemoveRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		sendError(w, http.StatusBadRequest, models.INVALID_INPUT, "invalid request payload")
+		return
+	}
+
+	resp, err := h.teamService.RemoveTeamMembers(r.Context(), req)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		sendError(w, http.StatusInternalServerError, models.INTERNAL_ERROR, "internal server error")
+		return
+	}
+}
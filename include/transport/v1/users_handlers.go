@@ -4,14 +4,28 @@ import (
 	"context"
 	"encoding/json"
 	"net/http"
+	"strconv"
 
 	"github.com/RomanKovalev007/pull_request_service/include/models"
 	transport "github.com/RomanKovalev007/pull_request_service/include/transport/models"
 )
 
 type UserService interface {
-	GetUserPullRequests(ctx context.Context, userID string) (*transport.UserPRsResponse, error)
+	GetUserPullRequests(ctx context.Context, req transport.UserPRsRequest) (*transport.UserPRsResponse, error)
 	SetUserIsActive(ctx context.Context, req transport.UserSetActiveRequest) (*transport.UserSetActiveResponse, error)
+	SetUserCapacity(ctx context.Context, req transport.UserSetCapacityRequest) (*transport.UserSetCapacityResponse, error)
+	MoveUserTeam(ctx context.Context, req transport.UserMoveTeamRequest) (*transport.UserMoveTeamResponse, error)
+	GetUser(ctx context.Context, userID string) (*transport.UserResponse, error)
+	ListUsers(ctx context.Context, filter models.UserFilter) (*transport.UserListResponse, error)
+	CreateUser(ctx context.Context, req transport.UserCreateRequest) (*transport.UserResponse, error)
+	UpdateUser(ctx context.Context, req transport.UserUpdateRequest) (*transport.UserUpdateResponse, error)
+	AddUserTags(ctx context.Context, req transport.UserTagsRequest) (*transport.UserResponse, error)
+	RemoveUserTags(ctx context.Context, req transport.UserTagsRequest) (*transport.UserResponse, error)
+
+	AddAvailability(ctx context.Context, req models.Availability) (*transport.AvailabilityResponse, error)
+	GetUserAvailability(ctx context.Context, userID string) (*transport.UserAvailabilityResponse, error)
+	UpdateAvailability(ctx context.Context, req models.Availability) (*transport.AvailabilityResponse, error)
+	DeleteAvailability(ctx context.Context, req transport.AvailabilityDeleteRequest) error
 }
 
 type UserHandler struct {
@@ -43,21 +57,167 @@ func (h *UserHandler) SetUserIsActive(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+func (h *UserHandler) SetUserCapacity(w http.ResponseWriter, r *http.Request) {
+	var req transport.UserSetCapacityRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		sendError(w, http.StatusBadRequest, models.INVALID_INPUT, "invalid request payload")
+		return
+	}
+
+	result_user, err := h.userService.SetUserCapacity(r.Context(), req)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(result_user); err != nil {
+		sendError(w, http.StatusInternalServerError, models.INTERNAL_ERROR, "internal server error")
+		return
+	}
+}
+
+func (h *UserHandler) MoveUserTeam(w http.ResponseWriter, r *http.Request) {
+	var req transport.UserMoveTeamRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		sendError(w, http.StatusBadRequest, models.INVALID_INPUT, "invalid request payload")
+		return
+	}
+
+	resp, err := h.userService.MoveUserTeam(r.Context(), req)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		sendError(w, http.StatusInternalServerError, models.INTERNAL_ERROR, "internal server error")
+		return
+	}
+}
+
 func (h *UserHandler) GetUserPullRequests(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	req := transport.UserPRsRequest{
+		UserID: query.Get("user_id"),
+		Status: query.Get("status"),
+		Cursor: query.Get("cursor"),
+	}
+	if req.UserID == "" {
+		sendError(w, http.StatusBadRequest, models.INVALID_INPUT, "invalid request payload")
+		return
+	}
+
+	var err error
+	if req.Limit, err = queryInt(query, "limit"); err != nil {
+		sendError(w, http.StatusBadRequest, models.INVALID_INPUT, "limit must be an integer")
+		return
+	}
+	if req.Since, err = queryTime(query, "since"); err != nil {
+		sendError(w, http.StatusBadRequest, models.INVALID_INPUT, "since must be an RFC 3339 timestamp")
+		return
+	}
+
+	prs, err := h.userService.GetUserPullRequests(r.Context(), req)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(prs); err != nil {
+		sendError(w, http.StatusInternalServerError, models.INTERNAL_ERROR, "internal server error")
+		return
+	}
+}
+
+func (h *UserHandler) GetUser(w http.ResponseWriter, r *http.Request) {
 	userID := r.URL.Query().Get("user_id")
 	if userID == "" {
 		sendError(w, http.StatusBadRequest, models.INVALID_INPUT, "invalid request payload")
 		return
 	}
 
-	prs, err := h.userService.GetUserPullRequests(r.Context(), userID)
+	user, err := h.userService.GetUser(r.Context(), userID)
 	if err != nil {
 		handleServiceError(w, err)
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	if err := json.NewEncoder(w).Encode(prs); err != nil {
+	if err := json.NewEncoder(w).Encode(user); err != nil {
+		sendError(w, http.StatusInternalServerError, models.INTERNAL_ERROR, "internal server error")
+		return
+	}
+}
+
+func (h *UserHandler) ListUsers(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := models.UserFilter{TeamName: query.Get("team_name")}
+
+	if value := query.Get("is_active"); value != "" {
+		isActive, err := strconv.ParseBool(value)
+		if err != nil {
+			sendError(w, http.StatusBadRequest, models.INVALID_INPUT, "is_active must be a boolean")
+			return
+		}
+		filter.IsActive = &isActive
+	}
+
+	users, err := h.userService.ListUsers(r.Context(), filter)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(users); err != nil {
+		sendError(w, http.StatusInternalServerError, models.INTERNAL_ERROR, "internal server error")
+		return
+	}
+}
+
+func (h *UserHandler) CreateUser(w http.ResponseWriter, r *http.Request) {
+	var req transport.UserCreateRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		sendError(w, http.StatusBadRequest, models.INVALID_INPUT, "invalid request payload")
+		return
+	}
+
+	user, err := h.userService.CreateUser(r.Context(), req)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(user); err != nil {
+		sendError(w, http.StatusInternalServerError, models.INTERNAL_ERROR, "internal server error")
+		return
+	}
+}
+
+func (h *UserHandler) UpdateUser(w http.ResponseWriter, r *http.Request) {
+	var req transport.UserUpdateRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		sendError(w, http.StatusBadRequest, models.INVALID_INPUT, "invalid request payload")
+		return
+	}
+
+	user, err := h.userService.UpdateUser(r.Context(), req)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(user); err != nil {
 		sendError(w, http.StatusInternalServerError, models.INTERNAL_ERROR, "internal server error")
 		return
 	}
@@ -0,0 +1,115 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/RomanKovalev007/pull_request_service/include/models"
+	transport "github.com/RomanKovalev007/pull_request_service/include/transport/models"
+)
+
+type WebhookService interface {
+	CreateWebhook(ctx context.Context, req transport.WebhookCreateRequest) (*transport.WebhookResponse, error)
+	ListWebhooks(ctx context.Context) (*transport.WebhookListResponse, error)
+	DeleteWebhook(ctx context.Context, req transport.WebhookDeleteRequest) error
+	ListDeadDeliveries(ctx context.Context, webhookID int64) (*transport.DeadDeliveriesResponse, error)
+	RetryDelivery(ctx context.Context, req transport.DeliveryRetryRequest) error
+}
+
+type WebhookHandler struct {
+	webhookService WebhookService
+}
+
+func NewWebhookHandler(webhookService WebhookService) *WebhookHandler {
+	return &WebhookHandler{webhookService: webhookService}
+}
+
+func (h *WebhookHandler) CreateWebhook(w http.ResponseWriter, r *http.Request) {
+	var req transport.WebhookCreateRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		sendError(w, http.StatusBadRequest, models.INVALID_INPUT, "invalid request payload")
+		return
+	}
+
+	resp, err := h.webhookService.CreateWebhook(r.Context(), req)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		sendError(w, http.StatusInternalServerError, models.INTERNAL_ERROR, "internal server error")
+		return
+	}
+}
+
+func (h *WebhookHandler) ListWebhooks(w http.ResponseWriter, r *http.Request) {
+	resp, err := h.webhookService.ListWebhooks(r.Context())
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		sendError(w, http.StatusInternalServerError, models.INTERNAL_ERROR, "internal server error")
+		return
+	}
+}
+
+func (h *WebhookHandler) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	var req transport.WebhookDeleteRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		sendError(w, http.StatusBadRequest, models.INVALID_INPUT, "invalid request payload")
+		return
+	}
+
+	if err := h.webhookService.DeleteWebhook(r.Context(), req); err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListDeadDeliveries показывает доставки, исчерпавшие попытки, с необязательным фильтром webhook_id.
+func (h *WebhookHandler) ListDeadDeliveries(w http.ResponseWriter, r *http.Request) {
+	webhookID, err := queryInt(r.URL.Query(), "webhook_id")
+	if err != nil {
+		sendError(w, http.StatusBadRequest, models.INVALID_INPUT, "webhook_id must be an integer")
+		return
+	}
+
+	resp, err := h.webhookService.ListDeadDeliveries(r.Context(), int64(webhookID))
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		sendError(w, http.StatusInternalServerError, models.INTERNAL_ERROR, "internal server error")
+		return
+	}
+}
+
+func (h *WebhookHandler) RetryDelivery(w http.ResponseWriter, r *http.Request) {
+	var req transport.DeliveryRetryRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		sendError(w, http.StatusBadRequest, models.INVALID_INPUT, "invalid request payload")
+		return
+	}
+
+	if err := h.webhookService.RetryDelivery(r.Context(), req); err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS team_settings (
+    team_name VARCHAR(255) PRIMARY KEY,
+    reviewer_count INTEGER NOT NULL DEFAULT 2,
+    min_reviewers INTEGER NOT NULL DEFAULT 1,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (team_name) REFERENCES teams(team_name) ON DELETE CASCADE,
+    CHECK (reviewer_count > 0 AND min_reviewers >= 0 AND min_reviewers <= reviewer_count)
+);
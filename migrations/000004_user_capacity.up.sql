@@ -0,0 +1 @@
+ALTER TABLE users ADD COLUMN IF NOT EXISTS review_capacity INTEGER NULL CHECK (review_capacity >= 0);
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS team_fallbacks (
+    team_name VARCHAR(255) NOT NULL,
+    fallback_team VARCHAR(255) NOT NULL,
+    position INTEGER NOT NULL,
+    PRIMARY KEY (team_name, fallback_team),
+    FOREIGN KEY (team_name) REFERENCES teams(team_name) ON DELETE CASCADE,
+    FOREIGN KEY (fallback_team) REFERENCES teams(team_name) ON DELETE CASCADE,
+    CHECK (team_name <> fallback_team)
+);
@@ -0,0 +1,20 @@
+-- пользователь может остаться без команды после удаления из неё
+ALTER TABLE users ALTER COLUMN team_name DROP NOT NULL;
+
+ALTER TABLE users
+    DROP CONSTRAINT IF EXISTS users_team_name_fkey,
+    ADD CONSTRAINT users_team_name_fkey FOREIGN KEY (team_name)
+        REFERENCES teams(team_name) ON UPDATE CASCADE ON DELETE SET NULL;
+
+ALTER TABLE team_settings
+    DROP CONSTRAINT IF EXISTS team_settings_team_name_fkey,
+    ADD CONSTRAINT team_settings_team_name_fkey FOREIGN KEY (team_name)
+        REFERENCES teams(team_name) ON UPDATE CASCADE ON DELETE CASCADE;
+
+ALTER TABLE team_fallbacks
+    DROP CONSTRAINT IF EXISTS team_fallbacks_team_name_fkey,
+    ADD CONSTRAINT team_fallbacks_team_name_fkey FOREIGN KEY (team_name)
+        REFERENCES teams(team_name) ON UPDATE CASCADE ON DELETE CASCADE,
+    DROP CONSTRAINT IF EXISTS team_fallbacks_fallback_team_fkey,
+    ADD CONSTRAINT team_fallbacks_fallback_team_fkey FOREIGN KEY (fallback_team)
+        REFERENCES teams(team_name) ON UPDATE CASCADE ON DELETE CASCADE;
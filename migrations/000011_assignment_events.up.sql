@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS assignment_events (
+    id BIGSERIAL PRIMARY KEY,
+    pull_request_id VARCHAR(255) NOT NULL,
+    action VARCHAR(50) NOT NULL CHECK (action IN ('assigned', 'reassigned', 'removed')),
+    actor VARCHAR(255) NOT NULL,
+    old_reviewer_id VARCHAR(255) NULL,
+    new_reviewer_id VARCHAR(255) NULL,
+    reason VARCHAR(50) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_assignment_events_pr ON assignment_events(pull_request_id, id);
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS reviewer_syncs (
+    pull_request_id VARCHAR(255) PRIMARY KEY,
+    status VARCHAR(50) NOT NULL CHECK (status IN ('PENDING', 'SYNCED', 'FAILED')),
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL DEFAULT '',
+    next_attempt_at TIMESTAMPTZ NULL,
+    synced_at TIMESTAMPTZ NULL,
+    FOREIGN KEY (pull_request_id) REFERENCES pull_requests(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_reviewer_syncs_due ON reviewer_syncs(next_attempt_at) WHERE status <> 'SYNCED';
@@ -0,0 +1,7 @@
+ALTER TABLE pull_requests ADD COLUMN IF NOT EXISTS repository VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE pull_requests ADD COLUMN IF NOT EXISTS source_branch VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE pull_requests ADD COLUMN IF NOT EXISTS target_branch VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE pull_requests ADD COLUMN IF NOT EXISTS description TEXT NOT NULL DEFAULT '';
+ALTER TABLE pull_requests ADD COLUMN IF NOT EXISTS url TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS idx_pr_repository ON pull_requests(repository);
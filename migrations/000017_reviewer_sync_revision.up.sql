@@ -0,0 +1 @@
+ALTER TABLE reviewer_syncs ADD COLUMN IF NOT EXISTS revision BIGINT NOT NULL DEFAULT 0;
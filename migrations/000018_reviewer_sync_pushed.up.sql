@@ -0,0 +1 @@
+ALTER TABLE reviewer_syncs ADD COLUMN IF NOT EXISTS pushed_reviewers TEXT[] NOT NULL DEFAULT '{}';
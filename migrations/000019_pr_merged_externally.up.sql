@@ -0,0 +1 @@
+ALTER TABLE pull_requests ADD COLUMN IF NOT EXISTS merged_externally BOOLEAN NOT NULL DEFAULT false;
@@ -0,0 +1,160 @@
+package integration
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/RomanKovalev007/pull_request_service/include/models"
+	transport "github.com/RomanKovalev007/pull_request_service/include/transport/models"
+)
+
+// Применённый импорт деактивирует всех пользователей вне roster, поэтому на общих
+// тестовых данных проверяется только план.
+func TestImportRoster_DryRun(t *testing.T) {
+	router := GetTestRouter()
+	if router == nil {
+		t.Fatal("Test router is not initialized")
+	}
+
+	rosters := []struct {
+		contentType string
+		body        string
+	}{
+		{"text/csv", "team,user_id,username,is_active\nimport-team,import-user,Import User,true\nbackend,user1,Alice Renamed,true\n"},
+		{"application/yaml", "- team: import-team\n  user_id: import-user\n  username: Import User\n- team: backend\n  user_id: user1\n  username: Alice Renamed\n"},
+	}
+
+	for _, roster := range rosters {
+		req := httptest.NewRequest("POST", "/admin/import?dry_run=true", strings.NewReader(roster.body))
+		req.Header.Set("Content-Type", roster.contentType)
+
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+
+		if rr.Code != http.StatusOK {
+			t.Fatalf("%s: expected status 200, got %d: %s", roster.contentType, rr.Code, rr.Body.String())
+		}
+
+		var response transport.ImportResponse
+		if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+
+		plan := response.Plan
+		if plan.Applied {
+			t.Errorf("%s: dry run must not apply the plan", roster.contentType)
+		}
+		if !slices.Contains(plan.TeamsCreated, "import-team") {
+			t.Errorf("%s: expected import-team to be created, got %v", roster.contentType, plan.TeamsCreated)
+		}
+		if len(plan.UsersCreated) != 1 || plan.UsersCreated[0].UserID != "import-user" || !plan.UsersCreated[0].IsActive {
+			t.Errorf("%s: expected active import-user to be created, got %+v", roster.contentType, plan.UsersCreated)
+		}
+		if len(plan.UsersUpdated) != 1 || plan.UsersUpdated[0].UserID != "user1" {
+			t.Errorf("%s: expected user1 to be updated, got %+v", roster.contentType, plan.UsersUpdated)
+		}
+		if slices.Contains(plan.UsersDeactivated, "user1") || !slices.Contains(plan.UsersDeactivated, "user2") {
+			t.Errorf("%s: expected users outside the roster to be deactivated, got %v", roster.contentType, plan.UsersDeactivated)
+		}
+	}
+
+	req := httptest.NewRequest("GET", "/team/get?team_name=import-team", nil)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	if rr.Code != http.StatusNotFound {
+		t.Errorf("Expected dry run to leave the database unchanged, got status %d", rr.Code)
+	}
+}
+
+func TestImportRoster_Invalid(t *testing.T) {
+	router := GetTestRouter()
+	if router == nil {
+		t.Fatal("Test router is not initialized")
+	}
+
+	bodies := []string{
+		"team,user_id\nbackend,user1\n",
+		"team,user_id,username\nbackend,user1,Alice\nbackend,user1,Alice\n",
+	}
+
+	for _, body := range bodies {
+		req := httptest.NewRequest("POST", "/admin/import?format=csv&dry_run=true", strings.NewReader(body))
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("Expected status 400 for invalid roster, got %d: %s", rr.Code, rr.Body.String())
+		}
+	}
+}
+
+func TestExportSnapshot(t *testing.T) {
+	router := GetTestRouter()
+	if router == nil {
+		t.Fatal("Test router is not initialized")
+	}
+
+	req := httptest.NewRequest("GET", "/admin/export", nil)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	var snap models.Snapshot
+	if err := json.Unmarshal(rr.Body.Bytes(), &snap); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+
+	if snap.Version != models.SnapshotVersion {
+		t.Errorf("Expected version %d, got %d", models.SnapshotVersion, snap.Version)
+	}
+	if !slices.ContainsFunc(snap.Teams, func(team models.SnapshotTeam) bool { return team.TeamName == "backend" }) {
+		t.Error("Expected backend team in export")
+	}
+	if !slices.ContainsFunc(snap.Users, func(user models.SnapshotUser) bool { return user.UserID == "user1" && user.TeamName == "backend" }) {
+		t.Error("Expected user1 of backend in export")
+	}
+
+	i := slices.IndexFunc(snap.PullRequests, func(pr models.SnapshotPullRequest) bool { return pr.PullRequestID == "pr3" })
+	if i < 0 {
+		t.Fatal("Expected pr3 in export")
+	}
+	if pr := snap.PullRequests[i]; pr.Status != "MERGED" || pr.MergedAt == nil || pr.CreatedAt == nil {
+		t.Errorf("Expected merged pr3 with timestamps, got %+v", pr)
+	}
+
+	// Тестовая база не пуста, поэтому загрузка той же выгрузки отклоняется.
+	restore := httptest.NewRecorder()
+	router.ServeHTTP(restore, httptest.NewRequest("POST", "/admin/restore", strings.NewReader(rr.Body.String())))
+	if restore.Code != http.StatusConflict {
+		t.Errorf("Expected status 409 on restore into non-empty database, got %d: %s", restore.Code, restore.Body.String())
+	}
+}
+
+func TestRestoreSnapshot_Invalid(t *testing.T) {
+	router := GetTestRouter()
+	if router == nil {
+		t.Fatal("Test router is not initialized")
+	}
+
+	bodies := []string{
+		`{"version": 99}`,
+		`{"version": 1, "users": [{"user_id": "u1", "username": "U1", "team_name": "missing"}]}`,
+		`{"version": 1, "pull_requests": [{"pull_request_id": "p1", "pull_request_name": "P1", "author_id": "missing"}]}`,
+		`not json`,
+	}
+
+	for _, body := range bodies {
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, httptest.NewRequest("POST", "/admin/restore", strings.NewReader(body)))
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("Expected status 400 for %s, got %d", body, rr.Code)
+		}
+	}
+}
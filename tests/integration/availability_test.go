@@ -0,0 +1,174 @@
+package integration
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/RomanKovalev007/pull_request_service/include/models"
+	transport "github.com/RomanKovalev007/pull_request_service/include/transport/models"
+)
+
+func TestAvailability_SkipsUnavailableReviewers(t *testing.T) {
+	router := GetTestRouter()
+	if router == nil {
+		t.Fatal("Test router is not initialized")
+	}
+
+	team := models.Team{
+		TeamName: "availability-team",
+		Members: []models.TeamMember{
+			{UserID: "availability-author", Username: "Availability Author", IsActive: true},
+			{UserID: "availability-away", Username: "Availability Away", IsActive: true},
+			{UserID: "availability-here", Username: "Availability Here", IsActive: true},
+		},
+	}
+
+	body, _ := json.Marshal(team)
+	req := httptest.NewRequest("POST", "/team/add", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("Failed to create team: %s", rr.Body.String())
+	}
+
+	// открытое ревью, назначенное до начала отпуска
+	db := GetTestDB()
+	seed := []string{
+		`INSERT INTO pull_requests (id, pull_request_name, author_id, status) VALUES ('availability-open', 'Open', 'availability-author', 'OPEN')`,
+		`INSERT INTO pr_reviewers (pull_request_id, reviewer_id) VALUES ('availability-open', 'availability-away')`,
+	}
+	for _, query := range seed {
+		if _, err := db.Exec(query); err != nil {
+			t.Fatalf("Failed to seed data: %v", err)
+		}
+	}
+
+	now := time.Now()
+	window := models.Availability{
+		UserID:          "availability-away",
+		StartsAt:        now.Add(-time.Hour),
+		EndsAt:          now.Add(time.Hour),
+		Reason:          "vacation",
+		ReassignReviews: true,
+	}
+
+	body, _ = json.Marshal(window)
+	req = httptest.NewRequest("POST", "/users/availability/add", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("Failed to add availability: %s", rr.Body.String())
+	}
+
+	createReq := transport.CreatePRRequest{
+		PullRequestID:   "availability-pr",
+		PullRequestName: "Availability PullRequest",
+		AuthorID:        "availability-author",
+	}
+
+	body, _ = json.Marshal(createReq)
+	req = httptest.NewRequest("POST", "/pullRequest/create", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("Failed to create PullRequest: %s", rr.Body.String())
+	}
+
+	var response transport.CreatePRResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+
+	for _, reviewer := range response.PullRequest.AssignedReviewers {
+		if reviewer == "availability-away" {
+			t.Error("Unavailable user must not be assigned")
+		}
+	}
+
+	pick := func(_ string, candidates []models.ReviewerCandidate, count int) []string {
+		var ids []string
+		for i := 0; i < len(candidates) && i < count; i++ {
+			ids = append(ids, candidates[i].UserID)
+		}
+		return ids
+	}
+
+	if _, err := TestRepo.UserRepository.ReassignUnavailableReviews(context.Background(), pick); err != nil {
+		t.Fatalf("Failed to reassign unavailable reviews: %v", err)
+	}
+
+	var reviewer string
+	if err := db.QueryRow(`SELECT reviewer_id FROM pr_reviewers WHERE pull_request_id = 'availability-open'`).Scan(&reviewer); err != nil {
+		t.Fatalf("Failed to select reviewer: %v", err)
+	}
+	if reviewer != "availability-here" {
+		t.Errorf("Expected review to be handed to availability-here, got %s", reviewer)
+	}
+
+	req = httptest.NewRequest("GET", "/users/availability/list?user_id=availability-away", nil)
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	var list transport.UserAvailabilityResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &list); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if len(list.Availability) != 1 || list.Availability[0].ReassignedAt == nil {
+		t.Errorf("Expected one processed availability window, got %+v", list.Availability)
+	}
+
+	t.Log("Unavailable reviewers are skipped and their reviews handed over")
+}
+
+func TestAvailability_InvalidPeriod(t *testing.T) {
+	router := GetTestRouter()
+	if router == nil {
+		t.Fatal("Test router is not initialized")
+	}
+
+	now := time.Now()
+	window := models.Availability{
+		UserID:   "user1",
+		StartsAt: now,
+		EndsAt:   now.Add(-time.Hour),
+	}
+
+	body, _ := json.Marshal(window)
+	req := httptest.NewRequest("POST", "/users/availability/add", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for inverted period, got %v", status)
+	}
+
+	deleteReq := transport.AvailabilityDeleteRequest{ID: 999999}
+	body, _ = json.Marshal(deleteReq)
+	req = httptest.NewRequest("POST", "/users/availability/delete", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusNotFound {
+		t.Errorf("Expected status 404 for non-existent availability, got %v", status)
+	}
+
+	t.Log("Invalid availability requests correctly rejected")
+}
@@ -0,0 +1,288 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/RomanKovalev007/pull_request_service/include/models"
+	"github.com/RomanKovalev007/pull_request_service/include/service"
+	transport "github.com/RomanKovalev007/pull_request_service/include/transport/models"
+)
+
+const githubTestToken = "github-test-token"
+
+// fakeGitHub — поддельный REST API GitHub, хранящий запрошенных ревьюверов по пути PR.
+type fakeGitHub struct {
+	*httptest.Server
+
+	mu        sync.Mutex
+	requested map[string][]string
+	// failures — сколько следующих запросов ответят 502.
+	failures int
+}
+
+func newFakeGitHub(t *testing.T) *fakeGitHub {
+	t.Helper()
+
+	fake := &fakeGitHub{requested: map[string][]string{}}
+	fake.Server = httptest.NewServer(http.HandlerFunc(fake.serve))
+	t.Cleanup(fake.Close)
+	return fake
+}
+
+func (f *fakeGitHub) serve(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if r.Header.Get("Authorization") != "Bearer "+githubTestToken {
+		http.Error(w, `{"message": "Bad credentials"}`, http.StatusUnauthorized)
+		return
+	}
+	if f.failures > 0 {
+		f.failures--
+		http.Error(w, `{"message": "Server Error"}`, http.StatusBadGateway)
+		return
+	}
+
+	path, ok := strings.CutSuffix(r.URL.Path, "/requested_reviewers")
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	var body struct {
+		Reviewers []string `json:"reviewers"`
+	}
+	if r.Method != http.MethodGet {
+		json.NewDecoder(r.Body).Decode(&body)
+	}
+
+	switch r.Method {
+	case http.MethodPost:
+		f.requested[path] = append(f.requested[path], body.Reviewers...)
+	case http.MethodDelete:
+		f.requested[path] = slices.DeleteFunc(f.requested[path], func(login string) bool {
+			return slices.Contains(body.Reviewers, login)
+		})
+	}
+
+	users := []map[string]string{}
+	for _, login := range f.requested[path] {
+		users = append(users, map[string]string{"login": login})
+	}
+	json.NewEncoder(w).Encode(map[string]any{"users": users, "teams": []any{}})
+}
+
+func (f *fakeGitHub) reviewers(path string) []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	logins := slices.Clone(f.requested[path])
+	slices.Sort(logins)
+	return logins
+}
+
+// request запрашивает ревьювера в обход сервиса, как это делает человек в интерфейсе GitHub.
+func (f *fakeGitHub) request(path, login string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.requested[path] = append(f.requested[path], login)
+}
+
+func (f *fakeGitHub) fail(n int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.failures = n
+}
+
+func codeHostConfig(fake *fakeGitHub) service.ReviewerConfig {
+	return service.ReviewerConfig{
+		GitHubToken:         githubTestToken,
+		GitHubAPIURL:        fake.URL,
+		GitHubLogins:        map[string]string{"octo-sync-rev1": "sync-rev1", "octo-retry-rev1": "retry-rev1"},
+		CodeHostMaxAttempts: 3,
+		CodeHostBackoffBase: time.Millisecond,
+		CodeHostBackoffMax:  time.Millisecond,
+	}
+}
+
+// expectedLogins переводит users.id в логины GitHub так же, как GITHUB_LOGINS в codeHostConfig.
+func expectedLogins(reviewers []string) []string {
+	logins := []string{}
+	for _, id := range reviewers {
+		if strings.HasSuffix(id, "-rev1") {
+			id = "octo-" + id
+		}
+		logins = append(logins, id)
+	}
+	slices.Sort(logins)
+	return logins
+}
+
+// withHuman добавляет к логинам ревьювера, запрошенного в GitHub вручную.
+func withHuman(logins []string) []string {
+	logins = append(logins, "octo-human")
+	slices.Sort(logins)
+	return logins
+}
+
+// createSyncTeam создаёт команду <prefix>-team из автора <prefix>-author и трёх ревьюверов.
+func createSyncTeam(t *testing.T, router http.Handler, prefix string) {
+	t.Helper()
+
+	team := models.Team{
+		TeamName: prefix + "-team",
+		Members: []models.TeamMember{
+			{UserID: prefix + "-author", Username: "Sync Author", IsActive: true},
+			{UserID: prefix + "-rev1", Username: "Sync Reviewer 1", IsActive: true},
+			{UserID: prefix + "-rev2", Username: "Sync Reviewer 2", IsActive: true},
+			{UserID: prefix + "-rev3", Username: "Sync Reviewer 3", IsActive: true},
+		},
+	}
+	if rr := postJSON(t, router, "/team/add", team); rr.Code != http.StatusCreated {
+		t.Fatalf("Failed to create team: %s", rr.Body.String())
+	}
+}
+
+func createSyncPR(t *testing.T, router http.Handler, prID, authorID string) models.PullRequest {
+	t.Helper()
+
+	rr := postJSON(t, router, "/pullRequest/create", transport.CreatePRRequest{
+		PullRequestID:   prID,
+		PullRequestName: "Sync " + prID,
+		AuthorID:        authorID,
+	})
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("Failed to create PR %s: %s", prID, rr.Body.String())
+	}
+
+	var created transport.CreatePRResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &created); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	return created.PullRequest
+}
+
+// runSyncWorker выполняет один проход фонового воркера выгрузки.
+func runSyncWorker(t *testing.T, syncer *service.ReviewerSyncService) {
+	t.Helper()
+
+	if err := syncer.RetryDue(context.Background()); err != nil {
+		t.Fatalf("Failed to sync reviewers: %v", err)
+	}
+}
+
+func TestReviewerSync_PushesToGitHub(t *testing.T) {
+	fake := newFakeGitHub(t)
+	cfg := codeHostConfig(fake)
+	router := newIntegrationRouter(t, cfg)
+	createSyncTeam(t, router, "sync")
+	syncer := service.NewReviewerSyncService(TestRepo.PrRepository, service.NewGitHubClient(cfg), cfg)
+
+	const path = "/repos/octo-org/sync/pulls/1"
+
+	// запрос только ставит выгрузку в очередь, GitHub вызывает воркер
+	pr := createSyncPR(t, router, "octo-org/sync#1", "sync-author")
+	if pr.ReviewerSync == nil || pr.ReviewerSync.Status != models.SyncPending {
+		t.Fatalf("Expected reviewer sync to be PENDING, got %+v", pr.ReviewerSync)
+	}
+	if got := fake.reviewers(path); len(got) != 0 {
+		t.Errorf("Expected no GitHub calls on the request path, got %v", got)
+	}
+
+	runSyncWorker(t, syncer)
+	if stored := getPullRequest(t, router, pr.PullRequestID).ReviewerSync; stored == nil || stored.Status != models.SyncSynced {
+		t.Fatalf("Expected reviewer sync to be SYNCED, got %+v", stored)
+	}
+	if got, want := fake.reviewers(path), expectedLogins(pr.AssignedReviewers); !slices.Equal(got, want) {
+		t.Errorf("Expected requested reviewers %v on GitHub, got %v", want, got)
+	}
+
+	// ревьювер, запрошенный вручную, не снимается при выгрузке
+	fake.request(path, "octo-human")
+
+	rr := postJSON(t, router, "/pullRequest/reassign", transport.ReassignRequest{
+		PullRequestID: pr.PullRequestID,
+		OldUserID:     pr.AssignedReviewers[0],
+	})
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Failed to reassign reviewer: %s", rr.Body.String())
+	}
+
+	runSyncWorker(t, syncer)
+	reviewers := getPullRequest(t, router, pr.PullRequestID).AssignedReviewers
+	if got, want := fake.reviewers(path), withHuman(expectedLogins(reviewers)); !slices.Equal(got, want) {
+		t.Errorf("Expected requested reviewers %v after reassign, got %v", want, got)
+	}
+
+	// изменения состава вне /pullRequest/reassign тоже выгружаются
+	rr = postJSON(t, router, "/users/setIsActive", transport.UserSetActiveRequest{UserID: reviewers[0], IsActive: false})
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Failed to deactivate reviewer: %s", rr.Body.String())
+	}
+	stored := getPullRequest(t, router, pr.PullRequestID)
+	if stored.ReviewerSync == nil || stored.ReviewerSync.Status != models.SyncPending {
+		t.Fatalf("Expected deactivation to queue reviewer sync, got %+v", stored.ReviewerSync)
+	}
+
+	runSyncWorker(t, syncer)
+	if got, want := fake.reviewers(path), withHuman(expectedLogins(stored.AssignedReviewers)); !slices.Equal(got, want) {
+		t.Errorf("Expected requested reviewers %v after deactivation, got %v", want, got)
+	}
+
+	// PR без ID GitHub не выгружаются
+	plain := createSyncPR(t, router, "sync-plain-1", "sync-author")
+	if plain.ReviewerSync != nil {
+		t.Errorf("Expected no reviewer sync for non-GitHub PR, got %+v", plain.ReviewerSync)
+	}
+}
+
+func TestReviewerSync_RetriesFailedPush(t *testing.T) {
+	fake := newFakeGitHub(t)
+	cfg := codeHostConfig(fake)
+	router := newIntegrationRouter(t, cfg)
+	createSyncTeam(t, router, "retry")
+	syncer := service.NewReviewerSyncService(TestRepo.PrRepository, service.NewGitHubClient(cfg), cfg)
+
+	fake.fail(1)
+	pr := createSyncPR(t, router, "octo-org/sync#2", "retry-author")
+	runSyncWorker(t, syncer)
+
+	// неудача видна в карточке PR
+	stored := getPullRequest(t, router, pr.PullRequestID).ReviewerSync
+	if stored == nil || stored.Status != models.SyncFailed || stored.Attempts != 1 ||
+		!strings.Contains(stored.LastError, "502") || stored.NextAttemptAt == nil {
+		t.Fatalf("Expected failed sync with 502 error and retry time, got %+v", stored)
+	}
+
+	time.Sleep(10 * time.Millisecond)
+	runSyncWorker(t, syncer)
+
+	stored = getPullRequest(t, router, pr.PullRequestID).ReviewerSync
+	if stored == nil || stored.Status != models.SyncSynced || stored.Attempts != 2 || stored.LastError != "" {
+		t.Errorf("Expected sync to succeed on retry, got %+v", stored)
+	}
+	if got, want := fake.reviewers("/repos/octo-org/sync/pulls/2"), expectedLogins(pr.AssignedReviewers); !slices.Equal(got, want) {
+		t.Errorf("Expected requested reviewers %v on GitHub, got %v", want, got)
+	}
+
+	// после CodeHostMaxAttempts попыток выгрузка больше не повторяется
+	fake.fail(100)
+	pr = createSyncPR(t, router, "octo-org/sync#3", "retry-author")
+	for range 4 {
+		runSyncWorker(t, syncer)
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	stored = getPullRequest(t, router, pr.PullRequestID).ReviewerSync
+	if stored == nil || stored.Status != models.SyncFailed || stored.Attempts != 3 || stored.NextAttemptAt != nil {
+		t.Errorf("Expected sync to give up after 3 attempts, got %+v", stored)
+	}
+}
@@ -0,0 +1,190 @@
+package integration
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/RomanKovalev007/pull_request_service/include/models"
+	"github.com/RomanKovalev007/pull_request_service/include/service"
+	transport "github.com/RomanKovalev007/pull_request_service/include/transport/models"
+)
+
+// createOwnersTeam создаёт команду с одним ревьювером на PR, чтобы выбор владельца был однозначным.
+func createOwnersTeam(t *testing.T, router http.Handler, team models.Team, fallbacks ...string) {
+	t.Helper()
+
+	if rr := postJSON(t, router, "/team/add", team); rr.Code != http.StatusCreated {
+		t.Fatalf("Failed to create team %s: %s", team.TeamName, rr.Body.String())
+	}
+
+	settings := models.TeamSettings{TeamName: team.TeamName, ReviewerCount: 1, MinReviewers: 1, FallbackTeams: fallbacks}
+	if rr := postJSON(t, router, "/team/settings", settings); rr.Code != http.StatusOK {
+		t.Fatalf("Failed to set team settings: %s", rr.Body.String())
+	}
+}
+
+func createPRWithFiles(t *testing.T, router http.Handler, req transport.CreatePRRequest) models.PullRequest {
+	t.Helper()
+
+	rr := postJSON(t, router, "/pullRequest/create", req)
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("Failed to create PR %s: %s", req.PullRequestID, rr.Body.String())
+	}
+
+	var created transport.CreatePRResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &created); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	return created.PullRequest
+}
+
+func TestCodeOwners_UploadedFile(t *testing.T) {
+	router := GetTestRouter()
+	if router == nil {
+		t.Fatal("Test router is not initialized")
+	}
+
+	createOwnersTeam(t, router, models.Team{
+		TeamName: "owners-platform",
+		Members: []models.TeamMember{
+			{UserID: "owners-fb1", Username: "Platform Engineer", IsActive: true},
+		},
+	})
+	createOwnersTeam(t, router, models.Team{
+		TeamName: "owners-team",
+		Members: []models.TeamMember{
+			{UserID: "owners-author", Username: "Owners Author", IsActive: true},
+			{UserID: "owners-billing", Username: "Billing Owner", IsActive: true},
+			{UserID: "owners-rev1", Username: "Owners Reviewer 1", IsActive: true},
+			{UserID: "owners-rev2", Username: "Owners Reviewer 2", IsActive: true},
+		},
+	}, "owners-platform")
+
+	codeowners := models.TeamCodeOwners{
+		TeamName: "owners-team",
+		Content:  "# владельцы\n* @owners-rev1\n/billing/ @owners-billing\n/infra/ @acme/owners-platform\n",
+	}
+	if rr := postJSON(t, router, "/team/codeowners", codeowners); rr.Code != http.StatusOK {
+		t.Fatalf("Failed to upload CODEOWNERS: %s", rr.Body.String())
+	}
+
+	req := httptest.NewRequest("GET", "/team/codeowners?team_name=owners-team", nil)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	var stored models.TeamCodeOwners
+	json.Unmarshal(rr.Body.Bytes(), &stored)
+	if rr.Code != http.StatusOK || stored.Content != codeowners.Content {
+		t.Errorf("Expected uploaded CODEOWNERS, got %d %+v", rr.Code, stored)
+	}
+
+	tests := []struct {
+		prID     string
+		files    []string
+		reviewer string
+	}{
+		{"owners-pr-1", []string{"billing/invoice.go"}, "owners-billing"},
+		{"owners-pr-2", []string{"billing/tax/rates.go"}, "owners-billing"},
+		{"owners-pr-3", []string{"README.md"}, "owners-rev1"},
+		// владельцы-команды берутся и из резервных команд
+		{"owners-pr-4", []string{"infra/terraform/main.tf"}, "owners-fb1"},
+	}
+	for _, tt := range tests {
+		pr := createPRWithFiles(t, router, transport.CreatePRRequest{
+			PullRequestID:   tt.prID,
+			PullRequestName: "Owners " + tt.prID,
+			AuthorID:        "owners-author",
+			ChangedFiles:    tt.files,
+		})
+		if !slices.Equal(pr.AssignedReviewers, []string{tt.reviewer}) {
+			t.Errorf("%s: expected reviewer %s for %v, got %v", tt.prID, tt.reviewer, tt.files, pr.AssignedReviewers)
+		}
+		if !slices.Equal(pr.ChangedFiles, tt.files) {
+			t.Errorf("%s: expected changed files %v, got %v", tt.prID, tt.files, pr.ChangedFiles)
+		}
+	}
+
+	// изменённые файлы черновика учитываются, когда он готов к ревью
+	draft := createPRWithFiles(t, router, transport.CreatePRRequest{
+		PullRequestID:   "owners-pr-draft",
+		PullRequestName: "Owners draft",
+		AuthorID:        "owners-author",
+		ChangedFiles:    []string{"billing/refunds.go"},
+		Draft:           true,
+	})
+	if len(draft.AssignedReviewers) != 0 {
+		t.Fatalf("Expected draft without reviewers, got %v", draft.AssignedReviewers)
+	}
+	if rr := changePRStatus(t, router, "/pullRequest/ready", "owners-pr-draft"); rr.Code != http.StatusOK {
+		t.Fatalf("Failed to mark PR ready: %s", rr.Body.String())
+	}
+	if pr := getPullRequest(t, router, "owners-pr-draft"); !slices.Equal(pr.AssignedReviewers, []string{"owners-billing"}) {
+		t.Errorf("Expected owner to review ready draft, got %v", pr.AssignedReviewers)
+	}
+}
+
+func TestCodeOwners_Validation(t *testing.T) {
+	router := GetTestRouter()
+	if router == nil {
+		t.Fatal("Test router is not initialized")
+	}
+
+	tests := []struct {
+		name       string
+		owners     models.TeamCodeOwners
+		wantStatus int
+	}{
+		{"owner without @", models.TeamCodeOwners{TeamName: "backend", Content: "* owners-rev1"}, http.StatusBadRequest},
+		{"negated pattern", models.TeamCodeOwners{TeamName: "backend", Content: "!docs/ @owners-rev1"}, http.StatusBadRequest},
+		{"unknown team", models.TeamCodeOwners{TeamName: "owners-missing", Content: "* @owners-rev1"}, http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if rr := postJSON(t, router, "/team/codeowners", tt.owners); rr.Code != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d: %s", tt.wantStatus, rr.Code, rr.Body.String())
+			}
+		})
+	}
+}
+
+func TestCodeOwners_Checkout(t *testing.T) {
+	checkout := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(checkout, ".github"), 0o755); err != nil {
+		t.Fatalf("Failed to create checkout: %v", err)
+	}
+	content := "*.sql @octo-dba\n"
+	if err := os.WriteFile(filepath.Join(checkout, ".github", "CODEOWNERS"), []byte(content), 0o644); err != nil {
+		t.Fatalf("Failed to write CODEOWNERS: %v", err)
+	}
+
+	router := newIntegrationRouter(t, service.ReviewerConfig{
+		CodeOwnersCheckouts: map[string]string{"checkout-team": checkout},
+		GitHubLogins:        map[string]string{"octo-dba": "checkout-dba"},
+	})
+
+	createOwnersTeam(t, router, models.Team{
+		TeamName: "checkout-team",
+		Members: []models.TeamMember{
+			{UserID: "checkout-author", Username: "Checkout Author", IsActive: true},
+			{UserID: "checkout-dba", Username: "Checkout DBA", IsActive: true},
+			{UserID: "checkout-rev1", Username: "Checkout Reviewer 1", IsActive: true},
+			{UserID: "checkout-rev2", Username: "Checkout Reviewer 2", IsActive: true},
+		},
+	})
+
+	for _, prID := range []string{"checkout-pr-1", "checkout-pr-2", "checkout-pr-3"} {
+		pr := createPRWithFiles(t, router, transport.CreatePRRequest{
+			PullRequestID:   prID,
+			PullRequestName: "Checkout " + prID,
+			AuthorID:        "checkout-author",
+			ChangedFiles:    []string{"migrations/000001_init.up.sql", "cmd/app/main.go"},
+		})
+		if !slices.Equal(pr.AssignedReviewers, []string{"checkout-dba"}) {
+			t.Errorf("%s: expected owner from checkout CODEOWNERS, got %v", prID, pr.AssignedReviewers)
+		}
+	}
+}
@@ -10,7 +10,7 @@ func TestDBTablesExist(t *testing.T) {
 		t.Fatal("TestDB is nil")
 	}
 
-	tables := []string{"teams", "users", "pull_requests", "pr_reviewers"}
+	tables := []string{"teams", "users", "pull_requests", "pr_reviewers", "team_settings", "team_fallbacks", "user_availability", "reviews"}
 	for _, table := range tables {
 		var exists bool
 		err := db.QueryRow(`
@@ -0,0 +1,162 @@
+package integration
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/RomanKovalev007/pull_request_service/include/models"
+	transport "github.com/RomanKovalev007/pull_request_service/include/transport/models"
+)
+
+func getAssignmentHistory(t *testing.T, router http.Handler, prID string) []models.AssignmentEvent {
+	t.Helper()
+
+	req := httptest.NewRequest("GET", "/pullRequest/history?pull_request_id="+prID, nil)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	var response transport.PRHistoryResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	return response.Events
+}
+
+func TestAssignmentHistory(t *testing.T) {
+	router := GetTestRouter()
+	if router == nil {
+		t.Fatal("Test router is not initialized")
+	}
+
+	team := models.Team{
+		TeamName: "history-team",
+		Members: []models.TeamMember{
+			{UserID: "history-author", Username: "History Author", IsActive: true},
+			{UserID: "history-rev1", Username: "History Reviewer 1", IsActive: true},
+			{UserID: "history-rev2", Username: "History Reviewer 2", IsActive: true},
+			{UserID: "history-rev3", Username: "History Reviewer 3", IsActive: true},
+		},
+	}
+	if rr := postJSON(t, router, "/team/add", team); rr.Code != http.StatusCreated {
+		t.Fatalf("Failed to create team: %s", rr.Body.String())
+	}
+
+	rr := postJSON(t, router, "/pullRequest/create", transport.CreatePRRequest{
+		PullRequestID:   "history-pr",
+		PullRequestName: "History PullRequest",
+		AuthorID:        "history-author",
+	})
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("Failed to create PR: %s", rr.Body.String())
+	}
+
+	var created transport.CreatePRResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &created); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if len(created.PullRequest.AssignedReviewers) != 2 {
+		t.Fatalf("Expected 2 reviewers, got %v", created.PullRequest.AssignedReviewers)
+	}
+	oldReviewer := created.PullRequest.AssignedReviewers[0]
+
+	body, _ := json.Marshal(transport.ReassignRequest{PullRequestID: "history-pr", OldUserID: oldReviewer})
+	req := httptest.NewRequest("POST", "/pullRequest/reassign", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Actor", "team-lead")
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Failed to reassign reviewer: %s", rr.Body.String())
+	}
+
+	if rr := changePRStatus(t, router, "/pullRequest/close", "history-pr"); rr.Code != http.StatusOK {
+		t.Fatalf("Failed to close PR: %s", rr.Body.String())
+	}
+
+	events := getAssignmentHistory(t, router, "history-pr")
+	if len(events) != 5 {
+		t.Fatalf("Expected 5 events (2 assigned, 1 reassigned, 2 removed), got %+v", events)
+	}
+
+	for _, e := range events[:2] {
+		if e.Action != models.AssignmentAssigned || e.Reason != models.ReasonPRCreated || e.Actor != models.SystemActor {
+			t.Errorf("Expected assignment on creation by system, got %+v", e)
+		}
+	}
+
+	reassigned := events[2]
+	if reassigned.Action != models.AssignmentReassigned || reassigned.Actor != "team-lead" ||
+		reassigned.OldReviewerID != oldReviewer || reassigned.NewReviewerID == "" ||
+		reassigned.Reason != models.ReasonManualReassign {
+		t.Errorf("Expected manual reassignment by team-lead, got %+v", reassigned)
+	}
+
+	for _, e := range events[3:] {
+		if e.Action != models.AssignmentRemoved || e.Reason != models.ReasonPRClosed || e.OldReviewerID == "" {
+			t.Errorf("Expected removal on close, got %+v", e)
+		}
+		if e.OldReviewerID == oldReviewer {
+			t.Errorf("Reviewer %s was already replaced and must not be removed on close", oldReviewer)
+		}
+	}
+}
+
+func TestAssignmentHistory_UserDeactivated(t *testing.T) {
+	router := GetTestRouter()
+	if router == nil {
+		t.Fatal("Test router is not initialized")
+	}
+
+	team := models.Team{
+		TeamName: "history-deactivate-team",
+		Members: []models.TeamMember{
+			{UserID: "history-deactivate-author", Username: "Author", IsActive: true},
+			{UserID: "history-deactivate-rev", Username: "Reviewer", IsActive: true},
+		},
+	}
+	if rr := postJSON(t, router, "/team/add", team); rr.Code != http.StatusCreated {
+		t.Fatalf("Failed to create team: %s", rr.Body.String())
+	}
+
+	rr := postJSON(t, router, "/pullRequest/create", transport.CreatePRRequest{
+		PullRequestID:   "history-deactivate-pr",
+		PullRequestName: "History Deactivate PullRequest",
+		AuthorID:        "history-deactivate-author",
+	})
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("Failed to create PR: %s", rr.Body.String())
+	}
+
+	rr = postJSON(t, router, "/users/setIsActive", map[string]any{"user_id": "history-deactivate-rev", "is_active": false})
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Failed to deactivate user: %s", rr.Body.String())
+	}
+
+	events := getAssignmentHistory(t, router, "history-deactivate-pr")
+	last := events[len(events)-1]
+	if last.Action != models.AssignmentRemoved || last.OldReviewerID != "history-deactivate-rev" || last.Reason != models.ReasonUserDeactivated {
+		t.Errorf("Expected removal of deactivated reviewer, got %+v", last)
+	}
+}
+
+func TestAssignmentHistory_NotFound(t *testing.T) {
+	router := GetTestRouter()
+	if router == nil {
+		t.Fatal("Test router is not initialized")
+	}
+
+	req := httptest.NewRequest("GET", "/pullRequest/history?pull_request_id=nonexistent-pr", nil)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusNotFound {
+		t.Errorf("Expected status 404, got %d", rr.Code)
+	}
+}
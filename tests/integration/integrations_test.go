@@ -0,0 +1,319 @@
+package integration
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/RomanKovalev007/pull_request_service/include/models"
+	"github.com/RomanKovalev007/pull_request_service/include/service"
+	transport "github.com/RomanKovalev007/pull_request_service/include/transport/models"
+	v1 "github.com/RomanKovalev007/pull_request_service/include/transport/v1"
+)
+
+const githubTestSecret = "github-test-secret"
+
+func newIntegrationRouter(t *testing.T, cfg service.ReviewerConfig) http.Handler {
+	t.Helper()
+
+	server, err := v1.NewServer("8080", TestRepo, cfg)
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+	if err := server.RegisterHandlers(); err != nil {
+		t.Fatalf("Failed to register handlers: %v", err)
+	}
+	return server.GetRouter()
+}
+
+// sendGitHubEvent отправляет записанный payload из testdata/github, подписанный secret.
+func sendGitHubEvent(t *testing.T, router http.Handler, event, fixture, secret string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	body, err := os.ReadFile(filepath.Join("testdata", "github", fixture))
+	if err != nil {
+		t.Fatalf("Failed to read fixture: %v", err)
+	}
+
+	req := httptest.NewRequest("POST", "/integrations/github/webhook", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-GitHub-Event", event)
+	req.Header.Set("X-Hub-Signature-256", service.Sign(secret, body))
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	return rr
+}
+
+func getPullRequest(t *testing.T, router http.Handler, prID string) models.PullRequest {
+	t.Helper()
+
+	req := httptest.NewRequest("GET", "/pullRequest/get?pull_request_id="+url.QueryEscape(prID), nil)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Failed to get PR %s: %d %s", prID, rr.Code, rr.Body.String())
+	}
+
+	var response transport.PRGetResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	return response.PullRequest
+}
+
+func TestGitHubWebhook_PullRequestFlow(t *testing.T) {
+	router := newIntegrationRouter(t, service.ReviewerConfig{
+		GitHubWebhookSecret: githubTestSecret,
+		GitHubLogins:        map[string]string{"octo-author": "github-author"},
+	})
+
+	team := models.Team{
+		TeamName: "github-team",
+		Members: []models.TeamMember{
+			{UserID: "github-author", Username: "GitHub Author", IsActive: true},
+			{UserID: "github-reviewer", Username: "GitHub Reviewer", IsActive: true},
+		},
+	}
+	if rr := postJSON(t, router, "/team/add", team); rr.Code != http.StatusCreated {
+		t.Fatalf("Failed to create team: %s", rr.Body.String())
+	}
+
+	const prID = "octo-org/widgets#7"
+
+	steps := []struct {
+		fixture string
+		result  string
+		status  string
+	}{
+		{"pull_request_opened.json", service.IntegrationCreated, models.StatusDraft},
+		{"pull_request_opened.json", service.IntegrationIgnored, models.StatusDraft},
+		{"pull_request_ready_for_review.json", service.IntegrationReady, models.StatusOpen},
+		{"pull_request_closed_merged.json", service.IntegrationMerged, models.StatusMerged},
+	}
+
+	for _, step := range steps {
+		rr := sendGitHubEvent(t, router, "pull_request", step.fixture, githubTestSecret)
+		if rr.Code != http.StatusOK {
+			t.Fatalf("%s: expected status 200, got %d: %s", step.fixture, rr.Code, rr.Body.String())
+		}
+
+		var response transport.IntegrationResponse
+		if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+		if response.Result != step.result || response.PullRequestID != prID {
+			t.Errorf("%s: expected %s of %s, got %+v", step.fixture, step.result, prID, response)
+		}
+
+		pr := getPullRequest(t, router, prID)
+		if pr.Status != step.status {
+			t.Errorf("%s: expected status %s, got %s", step.fixture, step.status, pr.Status)
+		}
+		if pr.AuthorID != "github-author" {
+			t.Errorf("Expected mapped author github-author, got %s", pr.AuthorID)
+		}
+	}
+
+	pr := getPullRequest(t, router, prID)
+	if len(pr.AssignedReviewers) != 1 || pr.AssignedReviewers[0] != "github-reviewer" {
+		t.Errorf("Expected github-reviewer to be assigned on ready_for_review, got %v", pr.AssignedReviewers)
+	}
+	if pr.Repository != "octo-org/widgets" || pr.SourceBranch != "widget-cache" || pr.TargetBranch != "main" ||
+		pr.URL != "https://github.com/octo-org/widgets/pull/7" || pr.Description != "Caches rendered widgets for five minutes." {
+		t.Errorf("Expected PR metadata from the opened event, got %+v", pr)
+	}
+	if !pr.MergedExternally || pr.ForceMerged {
+		t.Errorf("Expected external merge without force_merged when the gate is satisfied, got merged_externally=%v force_merged=%v",
+			pr.MergedExternally, pr.ForceMerged)
+	}
+
+	req := httptest.NewRequest("GET", "/pullRequest/history?pull_request_id="+url.QueryEscape(prID), nil)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	var history transport.PRHistoryResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &history); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if len(history.Events) == 0 || history.Events[0].Actor != "github:octo-author" {
+		t.Errorf("Expected assignment by github:octo-author, got %+v", history.Events)
+	}
+}
+
+func TestGitHubWebhook_Signature(t *testing.T) {
+	router := newIntegrationRouter(t, service.ReviewerConfig{GitHubWebhookSecret: githubTestSecret})
+
+	if rr := sendGitHubEvent(t, router, "pull_request", "pull_request_opened.json", "wrong-secret"); rr.Code != http.StatusUnauthorized {
+		t.Errorf("Expected status 401 for invalid signature, got %d", rr.Code)
+	}
+
+	if rr := sendGitHubEvent(t, router, "pull_request", "pull_request_opened.json", ""); rr.Code != http.StatusUnauthorized {
+		t.Errorf("Expected status 401 when signed with an empty secret, got %d", rr.Code)
+	}
+
+	rr := sendGitHubEvent(t, router, "ping", "pull_request_opened.json", githubTestSecret)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected status 200 for ping, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var response transport.IntegrationResponse
+	json.Unmarshal(rr.Body.Bytes(), &response)
+	if response.Result != service.IntegrationIgnored {
+		t.Errorf("Expected non pull_request events to be ignored, got %+v", response)
+	}
+
+	// без настроенного секрета endpoint не принимает события
+	unconfigured := newIntegrationRouter(t, service.ReviewerConfig{})
+	if rr := sendGitHubEvent(t, unconfigured, "pull_request", "pull_request_opened.json", ""); rr.Code != http.StatusUnauthorized {
+		t.Errorf("Expected status 401 without configured secret, got %d", rr.Code)
+	}
+}
+
+const gitlabTestToken = "gitlab-test-token"
+
+// sendGitLabEvent отправляет записанный payload из testdata/gitlab. project, если задан,
+// подменяет path_with_namespace проекта.
+func sendGitLabEvent(t *testing.T, router http.Handler, fixture, project, token string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	body, err := os.ReadFile(filepath.Join("testdata", "gitlab", fixture))
+	if err != nil {
+		t.Fatalf("Failed to read fixture: %v", err)
+	}
+
+	if project != "" {
+		var payload map[string]any
+		if err := json.Unmarshal(body, &payload); err != nil {
+			t.Fatalf("Failed to parse fixture: %v", err)
+		}
+		payload["project"].(map[string]any)["path_with_namespace"] = project
+		body, _ = json.Marshal(payload)
+	}
+
+	req := httptest.NewRequest("POST", "/integrations/gitlab/webhook", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Gitlab-Event", "Merge Request Hook")
+	req.Header.Set("X-Gitlab-Token", token)
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	return rr
+}
+
+func TestGitLabWebhook_MergeRequestFlow(t *testing.T) {
+	router := newIntegrationRouter(t, service.ReviewerConfig{
+		GitLabWebhookToken: gitlabTestToken,
+		GitLabUsers:        map[string]string{"lab.author": "gitlab-author"},
+	})
+
+	team := models.Team{
+		TeamName: "gitlab-team",
+		Members: []models.TeamMember{
+			{UserID: "gitlab-author", Username: "GitLab Author", IsActive: true},
+			{UserID: "gitlab-reviewer", Username: "GitLab Reviewer", IsActive: true},
+		},
+	}
+	if rr := postJSON(t, router, "/team/add", team); rr.Code != http.StatusCreated {
+		t.Fatalf("Failed to create team: %s", rr.Body.String())
+	}
+	// Одобрений в MR нет, поэтому merge в GitLab обходит проверку и PR помечается force_merged
+	settings := models.TeamSettings{TeamName: "gitlab-team", ReviewerCount: 1, MinReviewers: 1, RequiredApprovals: 1}
+	if rr := postJSON(t, router, "/team/settings", settings); rr.Code != http.StatusOK {
+		t.Fatalf("Failed to set team settings: %s", rr.Body.String())
+	}
+
+	const prID = "platform/billing!12"
+
+	steps := []struct {
+		fixture   string
+		result    string
+		status    string
+		reviewers int
+	}{
+		{"merge_request_open.json", service.IntegrationCreated, models.StatusDraft, 0},
+		{"merge_request_update_ready.json", service.IntegrationReady, models.StatusOpen, 1},
+		{"merge_request_update_draft.json", service.IntegrationDraft, models.StatusDraft, 0},
+		{"merge_request_update_ready.json", service.IntegrationReady, models.StatusOpen, 1},
+		{"merge_request_merge.json", service.IntegrationMerged, models.StatusMerged, 1},
+	}
+
+	for _, step := range steps {
+		rr := sendGitLabEvent(t, router, step.fixture, "", gitlabTestToken)
+		if rr.Code != http.StatusOK {
+			t.Fatalf("%s: expected status 200, got %d: %s", step.fixture, rr.Code, rr.Body.String())
+		}
+
+		var response transport.IntegrationResponse
+		if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+		if response.Result != step.result || response.PullRequestID != prID {
+			t.Errorf("%s: expected %s of %s, got %+v", step.fixture, step.result, prID, response)
+		}
+
+		pr := getPullRequest(t, router, prID)
+		if pr.Status != step.status || len(pr.AssignedReviewers) != step.reviewers {
+			t.Errorf("%s: expected status %s with %d reviewers, got %s with %v",
+				step.fixture, step.status, step.reviewers, pr.Status, pr.AssignedReviewers)
+		}
+	}
+
+	pr := getPullRequest(t, router, prID)
+	if pr.Repository != "platform/billing" || pr.SourceBranch != "invoice-rounding" || pr.TargetBranch != "main" ||
+		pr.URL != "https://gitlab.example.com/platform/billing/-/merge_requests/12" {
+		t.Errorf("Expected MR metadata from the open event, got %+v", pr)
+	}
+	if !pr.MergedExternally || !pr.ForceMerged {
+		t.Errorf("Expected external merge to be force_merged when approvals are missing, got merged_externally=%v force_merged=%v",
+			pr.MergedExternally, pr.ForceMerged)
+	}
+}
+
+func TestGitLabWebhook_ProjectNamespacing(t *testing.T) {
+	router := newIntegrationRouter(t, service.ReviewerConfig{
+		GitLabWebhookToken: gitlabTestToken,
+		GitLabUsers:        map[string]string{"lab.author": "gitlab-ns-author"},
+	})
+
+	team := models.Team{
+		TeamName: "gitlab-ns-team",
+		Members: []models.TeamMember{
+			{UserID: "gitlab-ns-author", Username: "GitLab NS Author", IsActive: true},
+		},
+	}
+	if rr := postJSON(t, router, "/team/add", team); rr.Code != http.StatusCreated {
+		t.Fatalf("Failed to create team: %s", rr.Body.String())
+	}
+
+	// одинаковый IID в разных проектах даёт разные PR
+	for _, project := range []string{"payments/ledger", "payments/gateway"} {
+		rr := sendGitLabEvent(t, router, "merge_request_open.json", project, gitlabTestToken)
+		if rr.Code != http.StatusOK {
+			t.Fatalf("%s: expected status 200, got %d: %s", project, rr.Code, rr.Body.String())
+		}
+
+		var response transport.IntegrationResponse
+		json.Unmarshal(rr.Body.Bytes(), &response)
+		if response.Result != service.IntegrationCreated || response.PullRequestID != project+"!12" {
+			t.Errorf("%s: expected %s!12 to be created, got %+v", project, project, response)
+		}
+	}
+}
+
+func TestGitLabWebhook_Token(t *testing.T) {
+	router := newIntegrationRouter(t, service.ReviewerConfig{GitLabWebhookToken: gitlabTestToken})
+
+	if rr := sendGitLabEvent(t, router, "merge_request_open.json", "", "wrong-token"); rr.Code != http.StatusUnauthorized {
+		t.Errorf("Expected status 401 for invalid token, got %d", rr.Code)
+	}
+
+	unconfigured := newIntegrationRouter(t, service.ReviewerConfig{})
+	if rr := sendGitLabEvent(t, unconfigured, "merge_request_open.json", "", ""); rr.Code != http.StatusUnauthorized {
+		t.Errorf("Expected status 401 without configured token, got %d", rr.Code)
+	}
+}
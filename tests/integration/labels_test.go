@@ -0,0 +1,175 @@
+package integration
+
+import (
+	"encoding/json"
+	"net/http"
+	"slices"
+	"testing"
+
+	"github.com/RomanKovalev007/pull_request_service/include/models"
+	transport "github.com/RomanKovalev007/pull_request_service/include/transport/models"
+)
+
+func setUserTags(t *testing.T, router http.Handler, path, userID string, tags ...string) models.User {
+	t.Helper()
+
+	rr := postJSON(t, router, path, transport.UserTagsRequest{UserID: userID, Tags: tags})
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Failed to change tags of %s: %s", userID, rr.Body.String())
+	}
+
+	var resp transport.UserResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	return resp.User
+}
+
+func TestLabels_ReviewerSelection(t *testing.T) {
+	router := GetTestRouter()
+	if router == nil {
+		t.Fatal("Test router is not initialized")
+	}
+
+	createOwnersTeam(t, router, models.Team{
+		TeamName: "labels-team",
+		Members: []models.TeamMember{
+			{UserID: "labels-author", Username: "Labels Author", IsActive: true},
+			{UserID: "labels-sec", Username: "Security Reviewer", IsActive: true},
+			{UserID: "labels-db", Username: "Database Reviewer", IsActive: true},
+			{UserID: "labels-rev", Username: "Plain Reviewer", IsActive: true},
+		},
+	})
+	setUserTags(t, router, "/users/tags/add", "labels-sec", "security")
+	setUserTags(t, router, "/users/tags/add", "labels-db", "DB", " frontend ")
+
+	tests := []struct {
+		prID     string
+		labels   []string
+		reviewer string
+	}{
+		{"labels-pr-1", []string{"security"}, "labels-sec"},
+		{"labels-pr-2", []string{"Security", "docs"}, "labels-sec"},
+		{"labels-pr-3", []string{"db"}, "labels-db"},
+	}
+	for _, tt := range tests {
+		pr := createPRWithFiles(t, router, transport.CreatePRRequest{
+			PullRequestID:   tt.prID,
+			PullRequestName: "Labels " + tt.prID,
+			AuthorID:        "labels-author",
+			Labels:          tt.labels,
+		})
+		if !slices.Equal(pr.AssignedReviewers, []string{tt.reviewer}) {
+			t.Errorf("%s: expected reviewer %s for %v, got %v", tt.prID, tt.reviewer, tt.labels, pr.AssignedReviewers)
+		}
+		if pr.SelectionReasons[tt.reviewer] != models.SelectedByLabel {
+			t.Errorf("%s: expected reason %s, got %v", tt.prID, models.SelectedByLabel, pr.SelectionReasons)
+		}
+	}
+
+	// без подходящего тега ревьювер выбирается стратегией
+	pr := createPRWithFiles(t, router, transport.CreatePRRequest{
+		PullRequestID:   "labels-pr-4",
+		PullRequestName: "Labels without match",
+		AuthorID:        "labels-author",
+		Labels:          []string{"mobile"},
+	})
+	if len(pr.AssignedReviewers) != 1 || pr.SelectionReasons[pr.AssignedReviewers[0]] != models.SelectedByStrategy {
+		t.Errorf("Expected one reviewer picked by strategy, got %v %v", pr.AssignedReviewers, pr.SelectionReasons)
+	}
+
+	// метки, добавленные черновику, учитываются, когда он готов к ревью
+	draft := createPRWithFiles(t, router, transport.CreatePRRequest{
+		PullRequestID:   "labels-pr-draft",
+		PullRequestName: "Labels draft",
+		AuthorID:        "labels-author",
+		Draft:           true,
+	})
+	if len(draft.AssignedReviewers) != 0 {
+		t.Fatalf("Expected draft without reviewers, got %v", draft.AssignedReviewers)
+	}
+	rr := postJSON(t, router, "/pullRequest/labels/add", transport.PRLabelsRequest{PullRequestID: "labels-pr-draft", Labels: []string{"security"}})
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Failed to add labels: %s", rr.Body.String())
+	}
+	rr = changePRStatus(t, router, "/pullRequest/ready", "labels-pr-draft")
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Failed to mark draft ready: %s", rr.Body.String())
+	}
+	var readyResp transport.PRStatusResponse
+	json.Unmarshal(rr.Body.Bytes(), &readyResp)
+	ready := readyResp.PullRequest
+	if !slices.Equal(ready.AssignedReviewers, []string{"labels-sec"}) || ready.SelectionReasons["labels-sec"] != models.SelectedByLabel {
+		t.Errorf("Expected labels-sec picked by label, got %v %v", ready.AssignedReviewers, ready.SelectionReasons)
+	}
+}
+
+func TestLabels_CRUD(t *testing.T) {
+	router := GetTestRouter()
+	if router == nil {
+		t.Fatal("Test router is not initialized")
+	}
+
+	createOwnersTeam(t, router, models.Team{
+		TeamName: "labels-crud-team",
+		Members: []models.TeamMember{
+			{UserID: "labels-crud-author", Username: "Labels CRUD Author", IsActive: true},
+			{UserID: "labels-crud-rev", Username: "Labels CRUD Reviewer", IsActive: true},
+		},
+	})
+
+	user := setUserTags(t, router, "/users/tags/add", "labels-crud-rev", "Security", "db", "db")
+	if !slices.Equal(user.Tags, []string{"db", "security"}) {
+		t.Errorf("Expected normalized tags [db security], got %v", user.Tags)
+	}
+	user = setUserTags(t, router, "/users/tags/remove", "labels-crud-rev", "DB", "missing")
+	if !slices.Equal(user.Tags, []string{"security"}) {
+		t.Errorf("Expected tags [security], got %v", user.Tags)
+	}
+
+	pr := createPRWithFiles(t, router, transport.CreatePRRequest{
+		PullRequestID:   "labels-crud-pr",
+		PullRequestName: "Labels CRUD",
+		AuthorID:        "labels-crud-author",
+		Labels:          []string{"backend"},
+	})
+	if !slices.Equal(pr.Labels, []string{"backend"}) {
+		t.Errorf("Expected labels [backend], got %v", pr.Labels)
+	}
+
+	rr := postJSON(t, router, "/pullRequest/labels/add", transport.PRLabelsRequest{PullRequestID: "labels-crud-pr", Labels: []string{"api", "backend"}})
+	var resp transport.PRLabelsResponse
+	json.Unmarshal(rr.Body.Bytes(), &resp)
+	if rr.Code != http.StatusOK || !slices.Equal(resp.PullRequest.Labels, []string{"api", "backend"}) {
+		t.Errorf("Expected labels [api backend], got %d %v", rr.Code, resp.PullRequest.Labels)
+	}
+
+	rr = postJSON(t, router, "/pullRequest/labels/remove", transport.PRLabelsRequest{PullRequestID: "labels-crud-pr", Labels: []string{"backend"}})
+	json.Unmarshal(rr.Body.Bytes(), &resp)
+	if rr.Code != http.StatusOK || !slices.Equal(resp.PullRequest.Labels, []string{"api"}) {
+		t.Errorf("Expected labels [api], got %d %v", rr.Code, resp.PullRequest.Labels)
+	}
+
+	if got := getPullRequest(t, router, "labels-crud-pr"); !slices.Equal(got.Labels, []string{"api"}) {
+		t.Errorf("Expected stored labels [api], got %v", got.Labels)
+	}
+
+	tests := []struct {
+		name string
+		path string
+		body any
+		code int
+	}{
+		{"empty tag", "/users/tags/add", transport.UserTagsRequest{UserID: "labels-crud-rev", Tags: []string{" "}}, http.StatusBadRequest},
+		{"no tags", "/users/tags/remove", transport.UserTagsRequest{UserID: "labels-crud-rev"}, http.StatusBadRequest},
+		{"unknown user", "/users/tags/add", transport.UserTagsRequest{UserID: "labels-missing", Tags: []string{"db"}}, http.StatusNotFound},
+		{"empty label", "/pullRequest/labels/add", transport.PRLabelsRequest{PullRequestID: "labels-crud-pr", Labels: []string{""}}, http.StatusBadRequest},
+		{"unknown pr", "/pullRequest/labels/remove", transport.PRLabelsRequest{PullRequestID: "labels-missing", Labels: []string{"api"}}, http.StatusNotFound},
+		{"empty label on create", "/pullRequest/create", transport.CreatePRRequest{PullRequestID: "labels-crud-pr-2", PullRequestName: "Invalid", AuthorID: "labels-crud-author", Labels: []string{""}}, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		if rr := postJSON(t, router, tt.path, tt.body); rr.Code != tt.code {
+			t.Errorf("%s: expected %d, got %d: %s", tt.name, tt.code, rr.Code, rr.Body.String())
+		}
+	}
+}
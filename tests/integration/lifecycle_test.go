@@ -0,0 +1,186 @@
+package integration
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/RomanKovalev007/pull_request_service/include/models"
+	transport "github.com/RomanKovalev007/pull_request_service/include/transport/models"
+)
+
+func changePRStatus(t *testing.T, router http.Handler, path, prID string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	body, _ := json.Marshal(transport.PRStatusRequest{PullRequestID: prID})
+	req := httptest.NewRequest("POST", path, bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	return rr
+}
+
+func TestPullRequestLifecycle_DraftReadyCloseReopen(t *testing.T) {
+	router := GetTestRouter()
+	if router == nil {
+		t.Fatal("Test router is not initialized")
+	}
+
+	team := models.Team{
+		TeamName: "lifecycle-team",
+		Members: []models.TeamMember{
+			{UserID: "lifecycle-author", Username: "Lifecycle Author", IsActive: true},
+			{UserID: "lifecycle-reviewer", Username: "Lifecycle Reviewer", IsActive: true},
+		},
+	}
+
+	body, _ := json.Marshal(team)
+	req := httptest.NewRequest("POST", "/team/add", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("Failed to create team: %s", rr.Body.String())
+	}
+
+	createReq := transport.CreatePRRequest{
+		PullRequestID:   "lifecycle-pr",
+		PullRequestName: "Lifecycle PullRequest",
+		AuthorID:        "lifecycle-author",
+		Draft:           true,
+	}
+
+	body, _ = json.Marshal(createReq)
+	req = httptest.NewRequest("POST", "/pullRequest/create", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("Failed to create draft PullRequest: %s", rr.Body.String())
+	}
+
+	var created transport.CreatePRResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &created); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+
+	if created.PullRequest.Status != models.StatusDraft {
+		t.Errorf("Expected status %s, got %s", models.StatusDraft, created.PullRequest.Status)
+	}
+	if len(created.PullRequest.AssignedReviewers) != 0 {
+		t.Errorf("Expected no reviewers on draft, got %v", created.PullRequest.AssignedReviewers)
+	}
+
+	steps := []struct {
+		path      string
+		status    string
+		reviewers int
+	}{
+		{"/pullRequest/ready", models.StatusOpen, 1},
+		{"/pullRequest/close", models.StatusClosed, 0},
+		{"/pullRequest/reopen", models.StatusOpen, 1},
+	}
+
+	for _, step := range steps {
+		rr = changePRStatus(t, router, step.path, "lifecycle-pr")
+		if rr.Code != http.StatusOK {
+			t.Fatalf("%s: expected status 200, got %d: %s", step.path, rr.Code, rr.Body.String())
+		}
+
+		var response transport.PRStatusResponse
+		if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+
+		if response.PullRequest.Status != step.status {
+			t.Errorf("%s: expected status %s, got %s", step.path, step.status, response.PullRequest.Status)
+		}
+		if len(response.PullRequest.AssignedReviewers) != step.reviewers {
+			t.Errorf("%s: expected %d reviewers, got %v", step.path, step.reviewers, response.PullRequest.AssignedReviewers)
+		}
+	}
+}
+
+func TestPullRequestLifecycle_InvalidTransition(t *testing.T) {
+	router := GetTestRouter()
+	if router == nil {
+		t.Fatal("Test router is not initialized")
+	}
+
+	team := models.Team{
+		TeamName: "transition-team",
+		Members: []models.TeamMember{
+			{UserID: "transition-author", Username: "Transition Author", IsActive: true},
+			{UserID: "transition-reviewer", Username: "Transition Reviewer", IsActive: true},
+		},
+	}
+
+	body, _ := json.Marshal(team)
+	req := httptest.NewRequest("POST", "/team/add", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("Failed to create team: %s", rr.Body.String())
+	}
+
+	createReq := transport.CreatePRRequest{
+		PullRequestID:   "transition-pr",
+		PullRequestName: "Transition PullRequest",
+		AuthorID:        "transition-author",
+		Draft:           true,
+	}
+
+	body, _ = json.Marshal(createReq)
+	req = httptest.NewRequest("POST", "/pullRequest/create", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("Failed to create draft PullRequest: %s", rr.Body.String())
+	}
+
+	for _, path := range []string{"/pullRequest/reopen", "/pullRequest/merge"} {
+		rr = changePRStatus(t, router, path, "transition-pr")
+		if rr.Code != http.StatusConflict {
+			t.Fatalf("%s: expected status 409, got %d: %s", path, rr.Code, rr.Body.String())
+		}
+
+		var errorResponse models.ErrorResponse
+		if err := json.Unmarshal(rr.Body.Bytes(), &errorResponse); err != nil {
+			t.Fatalf("Failed to parse error response: %v", err)
+		}
+
+		if errorResponse.Error.Code != models.INVALIDSTATUS {
+			t.Errorf("%s: expected error code %s, got %s", path, models.INVALIDSTATUS, errorResponse.Error.Code)
+		}
+	}
+
+	// замена ревьювера и ревью черновика отклоняются по статусу, а не как NOT_ASSIGNED
+	requests := map[string]any{
+		"/pullRequest/reassign": transport.ReassignRequest{PullRequestID: "transition-pr", OldUserID: "transition-reviewer"},
+		"/pullRequest/review":   transport.ReviewRequest{PullRequestID: "transition-pr", ReviewerID: "transition-reviewer", State: models.ReviewApproved},
+	}
+	for path, payload := range requests {
+		rr = postJSON(t, router, path, payload)
+
+		var errorResponse models.ErrorResponse
+		if err := json.Unmarshal(rr.Body.Bytes(), &errorResponse); err != nil {
+			t.Fatalf("Failed to parse error response: %v", err)
+		}
+		if rr.Code != http.StatusConflict || errorResponse.Error.Code != models.INVALIDSTATUS {
+			t.Errorf("%s: expected 409 %s, got %d %s", path, models.INVALIDSTATUS, rr.Code, errorResponse.Error.Code)
+		}
+	}
+}
@@ -0,0 +1,187 @@
+package integration
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/RomanKovalev007/pull_request_service/include/models"
+	transport "github.com/RomanKovalev007/pull_request_service/include/transport/models"
+)
+
+func strPtr(s string) *string {
+	return &s
+}
+
+func listPullRequests(t *testing.T, router http.Handler, query string) []string {
+	t.Helper()
+
+	req := httptest.NewRequest("GET", "/pullRequest/list?"+query, nil)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("%s: expected status 200, got %d: %s", query, rr.Code, rr.Body.String())
+	}
+
+	var response transport.PRListResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+
+	ids := []string{}
+	for _, pr := range response.PullRequests {
+		ids = append(ids, pr.PullRequestID)
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+func TestPullRequestMetadata_CreateAndUpdate(t *testing.T) {
+	router := GetTestRouter()
+	if router == nil {
+		t.Fatal("Test router is not initialized")
+	}
+
+	createOwnersTeam(t, router, models.Team{
+		TeamName: "meta-team",
+		Members: []models.TeamMember{
+			{UserID: "meta-author", Username: "Meta Author", IsActive: true},
+			{UserID: "meta-rev", Username: "Meta Reviewer", IsActive: true},
+			{UserID: "meta-rev2", Username: "Meta Reviewer 2", IsActive: true},
+		},
+	})
+
+	pr := createPRWithFiles(t, router, transport.CreatePRRequest{
+		PullRequestID:   "meta-pr-1",
+		PullRequestName: "Fix invoice rounding",
+		AuthorID:        "meta-author",
+		Repository:      "acme/billing",
+		SourceBranch:    "invoice-rounding",
+		TargetBranch:    "main",
+		Description:     "Rounds invoice totals half-even.",
+		URL:             "https://github.com/acme/billing/pull/1001",
+		Labels:          []string{"bug"},
+	})
+	if pr.Repository != "acme/billing" || pr.SourceBranch != "invoice-rounding" || pr.TargetBranch != "main" ||
+		pr.Description != "Rounds invoice totals half-even." || pr.URL != "https://github.com/acme/billing/pull/1001" {
+		t.Errorf("Expected metadata to be stored, got %+v", pr)
+	}
+
+	update := transport.PRUpdateRequest{
+		PullRequestID:   "meta-pr-1",
+		PullRequestName: strPtr("Fix invoice rounding for EUR"),
+		TargetBranch:    strPtr("release-2.4"),
+		Labels:          &[]string{"Billing", "bug"},
+	}
+	rr := postJSON(t, router, "/pullRequest/update", update)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Failed to update PR: %s", rr.Body.String())
+	}
+	var updated transport.PRUpdateResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &updated); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+
+	got := getPullRequest(t, router, "meta-pr-1")
+	if got.PullRequestName != "Fix invoice rounding for EUR" || got.TargetBranch != "release-2.4" {
+		t.Errorf("Expected updated name and target branch, got %+v", got)
+	}
+	// поля, не переданные в запросе, не меняются
+	if got.Repository != "acme/billing" || got.SourceBranch != "invoice-rounding" || got.Description != "Rounds invoice totals half-even." {
+		t.Errorf("Expected other fields to be kept, got %+v", got)
+	}
+	if !slices.Equal(got.Labels, []string{"billing", "bug"}) {
+		t.Errorf("Expected labels [billing bug], got %v", got.Labels)
+	}
+	if !slices.Equal(got.AssignedReviewers, pr.AssignedReviewers) || got.Status != models.StatusOpen {
+		t.Errorf("Expected reviewers and status to be kept, got %v %s", got.AssignedReviewers, got.Status)
+	}
+
+	rr = postJSON(t, router, "/pullRequest/update", transport.PRUpdateRequest{PullRequestID: "meta-pr-1", Labels: &[]string{}})
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Failed to clear labels: %s", rr.Body.String())
+	}
+	if got := getPullRequest(t, router, "meta-pr-1"); len(got.Labels) != 0 {
+		t.Errorf("Expected labels to be cleared, got %v", got.Labels)
+	}
+
+	// ответ на переназначение содержит PR целиком
+	rr = postJSON(t, router, "/pullRequest/reassign", transport.ReassignRequest{PullRequestID: "meta-pr-1", OldUserID: pr.AssignedReviewers[0]})
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Failed to reassign reviewer: %s", rr.Body.String())
+	}
+	var reassigned transport.ReassignResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &reassigned); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if got := reassigned.PullRequest; got.Repository != "acme/billing" || got.TargetBranch != "release-2.4" ||
+		got.URL != "https://github.com/acme/billing/pull/1001" || got.Description != "Rounds invoice totals half-even." {
+		t.Errorf("Expected metadata in reassign response, got %+v", got)
+	}
+
+	tests := []struct {
+		name string
+		path string
+		body any
+		code int
+	}{
+		{"nothing to update", "/pullRequest/update", transport.PRUpdateRequest{PullRequestID: "meta-pr-1"}, http.StatusBadRequest},
+		{"empty name", "/pullRequest/update", transport.PRUpdateRequest{PullRequestID: "meta-pr-1", PullRequestName: strPtr("")}, http.StatusBadRequest},
+		{"invalid url", "/pullRequest/update", transport.PRUpdateRequest{PullRequestID: "meta-pr-1", URL: strPtr("ftp://example.com")}, http.StatusBadRequest},
+		{"empty label", "/pullRequest/update", transport.PRUpdateRequest{PullRequestID: "meta-pr-1", Labels: &[]string{" "}}, http.StatusBadRequest},
+		{"unknown pr", "/pullRequest/update", transport.PRUpdateRequest{PullRequestID: "meta-missing", Description: strPtr("text")}, http.StatusNotFound},
+		{"invalid url on create", "/pullRequest/create", transport.CreatePRRequest{PullRequestID: "meta-pr-invalid", PullRequestName: "Invalid", AuthorID: "meta-author", URL: "not a url"}, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		if rr := postJSON(t, router, tt.path, tt.body); rr.Code != tt.code {
+			t.Errorf("%s: expected %d, got %d: %s", tt.name, tt.code, rr.Code, rr.Body.String())
+		}
+	}
+}
+
+func TestPullRequestMetadata_ListFilters(t *testing.T) {
+	router := GetTestRouter()
+	if router == nil {
+		t.Fatal("Test router is not initialized")
+	}
+
+	createOwnersTeam(t, router, models.Team{
+		TeamName: "meta-list-team",
+		Members: []models.TeamMember{
+			{UserID: "meta-list-author", Username: "Meta List Author", IsActive: true},
+			{UserID: "meta-list-rev", Username: "Meta List Reviewer", IsActive: true},
+		},
+	})
+
+	for _, req := range []transport.CreatePRRequest{
+		{PullRequestID: "meta-list-1", PullRequestName: "Add ledger export", Repository: "meta-org/ledger", SourceBranch: "export", TargetBranch: "main", Labels: []string{"backend", "db"}},
+		{PullRequestID: "meta-list-2", PullRequestName: "Ledger UI", Repository: "meta-org/ledger", SourceBranch: "ui", TargetBranch: "release", Labels: []string{"frontend"}, Description: "New export button"},
+		{PullRequestID: "meta-list-3", PullRequestName: "Gateway timeout", Repository: "meta-org/gateway", SourceBranch: "timeouts", TargetBranch: "main", Labels: []string{"backend"}},
+		{PullRequestID: "meta-list-4", PullRequestName: "Raise quota to 100%", Repository: "meta-org/quota", SourceBranch: "quota", TargetBranch: "main"},
+	} {
+		req.AuthorID = "meta-list-author"
+		createPRWithFiles(t, router, req)
+	}
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"repository=meta-org/ledger", []string{"meta-list-1", "meta-list-2"}},
+		{"repository=meta-org/ledger&target_branch=main", []string{"meta-list-1"}},
+		{"author_id=meta-list-author&source_branch=timeouts", []string{"meta-list-3"}},
+		{"author_id=meta-list-author&label=backend", []string{"meta-list-1", "meta-list-3"}},
+		{"author_id=meta-list-author&label=backend&label=DB", []string{"meta-list-1"}},
+		{"author_id=meta-list-author&q=EXPORT", []string{"meta-list-1", "meta-list-2"}},
+		// % и _ ищутся буквально, а не как шаблон
+		{"author_id=meta-list-author&q=%25", []string{"meta-list-4"}},
+		{"author_id=meta-list-author&q=_", []string{}},
+	}
+	for _, tt := range tests {
+		if got := listPullRequests(t, router, tt.query); !slices.Equal(got, tt.want) {
+			t.Errorf("%s: expected %v, got %v", tt.query, tt.want, got)
+		}
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"github.com/RomanKovalev007/pull_request_service/include/models"
 	transport "github.com/RomanKovalev007/pull_request_service/include/transport/models"
 )
 
@@ -157,6 +158,85 @@ func TestMergePullRequest_Success(t *testing.T) {
 	t.Logf("PullRequestPullRequest merged successfully: %s at %v", response.PullRequest.PullRequestID, response.PullRequest.MergedAt)
 }
 
+func TestMergePullRequest_Idempotent(t *testing.T) {
+	router := GetTestRouter()
+	if router == nil {
+		t.Fatal("Test router is not initialized")
+	}
+
+	team := models.Team{
+		TeamName: "idempotent-team",
+		Members: []models.TeamMember{
+			{UserID: "idempotent-author", Username: "Idempotent Author", IsActive: true},
+			{UserID: "idempotent-reviewer", Username: "Idempotent Reviewer", IsActive: true},
+		},
+	}
+
+	body, _ := json.Marshal(team)
+	req := httptest.NewRequest("POST", "/team/add", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("Failed to create team: %s", rr.Body.String())
+	}
+
+	createReq := transport.CreatePRRequest{
+		PullRequestID:   "idempotent-pr",
+		PullRequestName: "Idempotent PullRequest",
+		AuthorID:        "idempotent-author",
+	}
+
+	body, _ = json.Marshal(createReq)
+	req = httptest.NewRequest("POST", "/pullRequest/create", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("Failed to create PullRequest: %s", rr.Body.String())
+	}
+
+	mergeReq := transport.MergePRRequest{
+		PullRequestID: "idempotent-pr",
+	}
+
+	var responses []transport.MergePRResponse
+	for i := 0; i < 2; i++ {
+		body, _ := json.Marshal(mergeReq)
+		req := httptest.NewRequest("POST", "/pullRequest/merge", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+
+		if status := rr.Code; status != http.StatusOK {
+			t.Fatalf("Merge %d returned wrong status code: got %v want %v: %s", i+1, status, http.StatusOK, rr.Body.String())
+		}
+
+		var response transport.MergePRResponse
+		if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+		responses = append(responses, response)
+	}
+
+	if !responses[0].Merged {
+		t.Error("Expected first call to perform the merge")
+	}
+	if responses[1].Merged {
+		t.Error("Expected repeated call not to perform the merge")
+	}
+
+	first, second := responses[0].PullRequest.MergedAt, responses[1].PullRequest.MergedAt
+	if first == nil || second == nil || !first.Equal(*second) {
+		t.Errorf("Expected merged_at to be preserved, got %v and %v", first, second)
+	}
+}
+
 func TestMergePullRequest_NotFound(t *testing.T) {
 	router := GetTestRouter()
 	if router == nil {
@@ -270,3 +350,109 @@ func TestReassignReviewer_NotAssigned(t *testing.T) {
 
 	t.Log("PullRequestNot assigned reviewer reassign correctly returns 409")
 }
+
+func TestGetPullRequest_Success(t *testing.T) {
+	router := GetTestRouter()
+	if router == nil {
+		t.Fatal("Test router is not initialized")
+	}
+
+	req := httptest.NewRequest("GET", "/pullRequest/get?pull_request_id=pr4", nil)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusOK {
+		t.Fatalf("Handler returned wrong status code: got %v want %v: %s", status, http.StatusOK, rr.Body.String())
+	}
+
+	var response transport.PRGetResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+
+	if response.PullRequest.PullRequestID != "pr4" {
+		t.Errorf("Expected pr4, got %s", response.PullRequest.PullRequestID)
+	}
+	if response.PullRequest.CreatedAt == nil {
+		t.Error("Expected CreatedAt to be set")
+	}
+	if response.PullRequest.AssignedReviewers == nil {
+		t.Error("Expected assigned_reviewers to be present")
+	}
+}
+
+func TestGetPullRequest_NotFound(t *testing.T) {
+	router := GetTestRouter()
+	if router == nil {
+		t.Fatal("Test router is not initialized")
+	}
+
+	req := httptest.NewRequest("GET", "/pullRequest/get?pull_request_id=nonexistent-pr", nil)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusNotFound {
+		t.Errorf("Expected status 404 for non-existent PullRequest, got %v", status)
+	}
+}
+
+func TestListPullRequests_Filters(t *testing.T) {
+	router := GetTestRouter()
+	if router == nil {
+		t.Fatal("Test router is not initialized")
+	}
+
+	req := httptest.NewRequest("GET", "/pullRequest/list?status=MERGED&author_id=user3&created_from=2000-01-01T00:00:00Z", nil)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusOK {
+		t.Fatalf("Handler returned wrong status code: got %v want %v: %s", status, http.StatusOK, rr.Body.String())
+	}
+
+	var response transport.PRListResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+
+	if response.Total != 1 || len(response.PullRequests) != 1 {
+		t.Fatalf("Expected exactly one PullRequest, got total %d: %+v", response.Total, response.PullRequests)
+	}
+	if response.PullRequests[0].PullRequestID != "pr3" {
+		t.Errorf("Expected pr3, got %s", response.PullRequests[0].PullRequestID)
+	}
+
+	req = httptest.NewRequest("GET", "/pullRequest/list?limit=1", nil)
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusOK {
+		t.Fatalf("Handler returned wrong status code: got %v want %v: %s", status, http.StatusOK, rr.Body.String())
+	}
+
+	response = transport.PRListResponse{}
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+
+	if len(response.PullRequests) != 1 || response.Total < 2 {
+		t.Errorf("Expected one PullRequest on the page out of several, got %d of %d", len(response.PullRequests), response.Total)
+	}
+}
+
+func TestListPullRequests_InvalidFilter(t *testing.T) {
+	router := GetTestRouter()
+	if router == nil {
+		t.Fatal("Test router is not initialized")
+	}
+
+	for _, query := range []string{"status=UNKNOWN", "limit=1000", "merged_from=yesterday"} {
+		req := httptest.NewRequest("GET", "/pullRequest/list?"+query, nil)
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+
+		if status := rr.Code; status != http.StatusBadRequest {
+			t.Errorf("%s: expected status 400, got %v", query, status)
+		}
+	}
+}
@@ -0,0 +1,260 @@
+package integration
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/RomanKovalev007/pull_request_service/include/models"
+	transport "github.com/RomanKovalev007/pull_request_service/include/transport/models"
+)
+
+func TestSubmitReview_Success(t *testing.T) {
+	router := GetTestRouter()
+	if router == nil {
+		t.Fatal("Test router is not initialized")
+	}
+
+	team := models.Team{
+		TeamName: "review-team",
+		Members: []models.TeamMember{
+			{UserID: "review-author", Username: "Review Author", IsActive: true},
+			{UserID: "review-reviewer", Username: "Review Reviewer", IsActive: true},
+		},
+	}
+
+	body, _ := json.Marshal(team)
+	req := httptest.NewRequest("POST", "/team/add", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("Failed to create team: %s", rr.Body.String())
+	}
+
+	createReq := transport.CreatePRRequest{
+		PullRequestID:   "review-pr",
+		PullRequestName: "Review PullRequest",
+		AuthorID:        "review-author",
+	}
+
+	body, _ = json.Marshal(createReq)
+	req = httptest.NewRequest("POST", "/pullRequest/create", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("Failed to create PullRequest: %s", rr.Body.String())
+	}
+
+	for _, state := range []string{models.ReviewChangesRequested, models.ReviewApproved} {
+		reviewReq := transport.ReviewRequest{
+			PullRequestID: "review-pr",
+			ReviewerID:    "review-reviewer",
+			State:         state,
+		}
+
+		body, _ = json.Marshal(reviewReq)
+		req = httptest.NewRequest("POST", "/pullRequest/review", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+
+		rr = httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+
+		if rr.Code != http.StatusCreated {
+			t.Fatalf("Failed to submit %s review: %s", state, rr.Body.String())
+		}
+	}
+
+	var response transport.ReviewResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+
+	if len(response.PullRequest.Reviews) != 1 {
+		t.Fatalf("Expected one latest review, got %+v", response.PullRequest.Reviews)
+	}
+
+	if latest := response.PullRequest.Reviews[0]; latest.ReviewerID != "review-reviewer" || latest.State != models.ReviewApproved {
+		t.Errorf("Expected latest verdict APPROVED by review-reviewer, got %+v", latest)
+	}
+
+	t.Log("Review submitted and latest verdict exposed on the PullRequest")
+}
+
+func TestSubmitReview_Rejected(t *testing.T) {
+	router := GetTestRouter()
+	if router == nil {
+		t.Fatal("Test router is not initialized")
+	}
+
+	cases := []struct {
+		name   string
+		req    transport.ReviewRequest
+		status int
+		code   models.ErrorResponseErrorCode
+	}{
+		{
+			name:   "not assigned",
+			req:    transport.ReviewRequest{PullRequestID: "pr4", ReviewerID: "user1", State: models.ReviewApproved},
+			status: http.StatusConflict,
+			code:   models.NOTASSIGNED,
+		},
+		{
+			name:   "merged",
+			req:    transport.ReviewRequest{PullRequestID: "pr3", ReviewerID: "user10", State: models.ReviewApproved},
+			status: http.StatusConflict,
+			code:   models.PRMERGED,
+		},
+		{
+			name:   "invalid state",
+			req:    transport.ReviewRequest{PullRequestID: "pr4", ReviewerID: "user5", State: "LGTM"},
+			status: http.StatusBadRequest,
+			code:   models.INVALID_INPUT,
+		},
+	}
+
+	for _, tc := range cases {
+		body, _ := json.Marshal(tc.req)
+		req := httptest.NewRequest("POST", "/pullRequest/review", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+
+		if rr.Code != tc.status {
+			t.Errorf("%s: expected status %v, got %v", tc.name, tc.status, rr.Code)
+			continue
+		}
+
+		var errResp models.ErrorResponse
+		if err := json.Unmarshal(rr.Body.Bytes(), &errResp); err != nil {
+			t.Fatalf("%s: failed to parse error response: %v", tc.name, err)
+		}
+		if errResp.Error.Code != tc.code {
+			t.Errorf("%s: expected code %s, got %s", tc.name, tc.code, errResp.Error.Code)
+		}
+	}
+
+	t.Log("Invalid review submissions correctly rejected")
+}
+
+func TestMergePullRequest_RequiresApprovals(t *testing.T) {
+	router := GetTestRouter()
+	if router == nil {
+		t.Fatal("Test router is not initialized")
+	}
+
+	team := models.Team{
+		TeamName: "gate-team",
+		Members: []models.TeamMember{
+			{UserID: "gate-author", Username: "Gate Author", IsActive: true},
+			{UserID: "gate-reviewer", Username: "Gate Reviewer", IsActive: true},
+		},
+	}
+
+	body, _ := json.Marshal(team)
+	req := httptest.NewRequest("POST", "/team/add", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("Failed to create team: %s", rr.Body.String())
+	}
+
+	settings := models.TeamSettings{TeamName: "gate-team", ReviewerCount: 1, MinReviewers: 1, RequiredApprovals: 1}
+	body, _ = json.Marshal(settings)
+	req = httptest.NewRequest("POST", "/team/settings", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Failed to set team settings: %s", rr.Body.String())
+	}
+
+	for _, prID := range []string{"gate-pr", "gate-pr-forced"} {
+		createReq := transport.CreatePRRequest{
+			PullRequestID:   prID,
+			PullRequestName: "Gate " + prID,
+			AuthorID:        "gate-author",
+		}
+
+		body, _ = json.Marshal(createReq)
+		req = httptest.NewRequest("POST", "/pullRequest/create", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+
+		rr = httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+
+		if rr.Code != http.StatusCreated {
+			t.Fatalf("Failed to create PullRequest %s: %s", prID, rr.Body.String())
+		}
+	}
+
+	mergeReq := transport.MergePRRequest{PullRequestID: "gate-pr"}
+	body, _ = json.Marshal(mergeReq)
+	req = httptest.NewRequest("POST", "/pullRequest/merge", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusConflict {
+		t.Fatalf("Expected status 409 for merge without approvals, got %v", status)
+	}
+
+	reviewReq := transport.ReviewRequest{PullRequestID: "gate-pr", ReviewerID: "gate-reviewer", State: models.ReviewApproved}
+	body, _ = json.Marshal(reviewReq)
+	req = httptest.NewRequest("POST", "/pullRequest/review", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("Failed to approve PullRequest: %s", rr.Body.String())
+	}
+
+	body, _ = json.Marshal(mergeReq)
+	req = httptest.NewRequest("POST", "/pullRequest/merge", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected approved PullRequest to merge: %s", rr.Body.String())
+	}
+
+	forceReq := transport.MergePRRequest{PullRequestID: "gate-pr-forced", Force: true}
+	body, _ = json.Marshal(forceReq)
+	req = httptest.NewRequest("POST", "/pullRequest/merge", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected forced merge to succeed: %s", rr.Body.String())
+	}
+
+	var response transport.MergePRResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if !response.PullRequest.ForceMerged {
+		t.Error("Expected forced merge to be recorded")
+	}
+
+	t.Log("Merge gate enforces approvals and records forced merges")
+}
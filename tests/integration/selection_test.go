@@ -0,0 +1,193 @@
+package integration
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/RomanKovalev007/pull_request_service/include/models"
+	"github.com/RomanKovalev007/pull_request_service/include/service"
+	transport "github.com/RomanKovalev007/pull_request_service/include/transport/models"
+	v1 "github.com/RomanKovalev007/pull_request_service/include/transport/v1"
+)
+
+func TestReviewerSelection_RoundRobin(t *testing.T) {
+	server, err := v1.NewServer("8080", TestRepo, service.ReviewerConfig{
+		ReviewerStrategy:       service.StrategyRandom,
+		TeamReviewerStrategies: map[string]string{"rr-team": service.StrategyRoundRobin},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+	if err := server.RegisterHandlers(); err != nil {
+		t.Fatalf("Failed to register handlers: %v", err)
+	}
+	router := server.GetRouter()
+
+	team := models.Team{
+		TeamName: "rr-team",
+		Members: []models.TeamMember{
+			{UserID: "rr-author", Username: "RR Author", IsActive: true},
+			{UserID: "rr-1", Username: "RR 1", IsActive: true},
+			{UserID: "rr-2", Username: "RR 2", IsActive: true},
+			{UserID: "rr-3", Username: "RR 3", IsActive: true},
+		},
+	}
+
+	body, _ := json.Marshal(team)
+	req := httptest.NewRequest("POST", "/team/add", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("Failed to create team: %s", rr.Body.String())
+	}
+
+	expected := [][]string{
+		{"rr-1", "rr-2"},
+		{"rr-3", "rr-1"},
+	}
+
+	for i, prID := range []string{"rr-pr-1", "rr-pr-2"} {
+		createReq := transport.CreatePRRequest{
+			PullRequestID:   prID,
+			PullRequestName: "Round robin " + prID,
+			AuthorID:        "rr-author",
+		}
+
+		body, _ := json.Marshal(createReq)
+		req := httptest.NewRequest("POST", "/pullRequest/create", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+
+		if rr.Code != http.StatusCreated {
+			t.Fatalf("Failed to create PullRequest %s: %s", prID, rr.Body.String())
+		}
+
+		var response transport.CreatePRResponse
+		if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+
+		if !slices.Equal(response.PullRequest.AssignedReviewers, expected[i]) {
+			t.Errorf("Expected reviewers %v for %s, got %v", expected[i], prID, response.PullRequest.AssignedReviewers)
+		}
+	}
+
+	t.Log("Round robin strategy rotates reviewers within the team")
+}
+
+func TestReviewerSelection_UnknownStrategy(t *testing.T) {
+	_, err := v1.NewServer("8080", TestRepo, service.ReviewerConfig{ReviewerStrategy: "unknown"})
+	if err == nil {
+		t.Fatal("Expected error for unknown reviewer strategy")
+	}
+
+	t.Logf("Unknown strategy correctly rejected: %v", err)
+}
+
+func TestReviewerSelection_LeastLoaded(t *testing.T) {
+	router := GetTestRouter()
+	if router == nil {
+		t.Fatal("Test router is not initialized")
+	}
+
+	team := models.Team{
+		TeamName: "ll-team",
+		Members: []models.TeamMember{
+			{UserID: "ll-author", Username: "LL Author", IsActive: true},
+			{UserID: "ll-1", Username: "LL 1", IsActive: true},
+			{UserID: "ll-2", Username: "LL 2", IsActive: true},
+			{UserID: "ll-3", Username: "LL 3", IsActive: true},
+		},
+	}
+
+	body, _ := json.Marshal(team)
+	req := httptest.NewRequest("POST", "/team/add", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("Failed to create team: %s", rr.Body.String())
+	}
+
+	// ll-1 занят открытым ревью, ll-2 недавно ревьюил смерженный PR, ll-3 свободен дольше всех
+	db := GetTestDB()
+	seed := []string{
+		`INSERT INTO pull_requests (id, pull_request_name, author_id, status) VALUES ('ll-open', 'Open', 'll-author', 'OPEN')`,
+		`INSERT INTO pull_requests (id, pull_request_name, author_id, status) VALUES ('ll-merged', 'Merged', 'll-author', 'MERGED')`,
+		`INSERT INTO pr_reviewers (pull_request_id, reviewer_id) VALUES ('ll-open', 'll-1')`,
+		`INSERT INTO pr_reviewers (pull_request_id, reviewer_id) VALUES ('ll-merged', 'll-2')`,
+	}
+	for _, query := range seed {
+		if _, err := db.Exec(query); err != nil {
+			t.Fatalf("Failed to seed data: %v", err)
+		}
+	}
+
+	createReq := transport.CreatePRRequest{
+		PullRequestID:   "ll-pr",
+		PullRequestName: "Least loaded",
+		AuthorID:        "ll-author",
+		Strategy:        service.StrategyLeastLoaded,
+	}
+
+	body, _ = json.Marshal(createReq)
+	req = httptest.NewRequest("POST", "/pullRequest/create", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("Failed to create PullRequest: %s", rr.Body.String())
+	}
+
+	var response transport.CreatePRResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+
+	expected := []string{"ll-3", "ll-2"}
+	if !slices.Equal(response.PullRequest.AssignedReviewers, expected) {
+		t.Errorf("Expected reviewers %v, got %v", expected, response.PullRequest.AssignedReviewers)
+	}
+
+	t.Log("Least loaded strategy prefers idle reviewers")
+}
+
+func TestReviewerSelection_InvalidStrategy(t *testing.T) {
+	router := GetTestRouter()
+	if router == nil {
+		t.Fatal("Test router is not initialized")
+	}
+
+	createReq := transport.CreatePRRequest{
+		PullRequestID:   "invalid-strategy-pr",
+		PullRequestName: "Invalid strategy",
+		AuthorID:        "user1",
+		Strategy:        "fastest",
+	}
+
+	body, _ := json.Marshal(createReq)
+	req := httptest.NewRequest("POST", "/pullRequest/create", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for unknown strategy, got %v", status)
+	}
+
+	t.Log("Unknown strategy in request correctly rejected")
+}
@@ -7,6 +7,7 @@ import (
 	"os"
 
 	"github.com/RomanKovalev007/pull_request_service/include/repository"
+	"github.com/RomanKovalev007/pull_request_service/include/service"
 	v1 "github.com/RomanKovalev007/pull_request_service/include/transport/v1"
 	"github.com/RomanKovalev007/pull_request_service/tests/integration/testutils"
 	_ "github.com/lib/pq"
@@ -14,6 +15,7 @@ import (
 
 var (
 	TestDB     *sql.DB
+	TestRepo   *repository.Repo
 	TestServer *v1.Server
 	TestConfig *testutils.DBConfig
 )
@@ -57,7 +59,13 @@ func setup() {
 		log.Fatalf("Failed to create newdb: %v", err)
 	}
 
-	TestServer = v1.NewServer("8080", repo)
+	TestRepo = repo
+
+	TestServer, err = v1.NewServer("8080", repo, service.ReviewerConfig{})
+	if err != nil {
+		log.Fatalf("Failed to create server: %v", err)
+	}
+
 	if err := TestServer.RegisterHandlers(); err != nil {
 		log.Fatalf("Failed to register handlers: %v", err)
 	}
@@ -5,9 +5,11 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"slices"
 	"testing"
 
 	"github.com/RomanKovalev007/pull_request_service/include/models"
+	transport "github.com/RomanKovalev007/pull_request_service/include/transport/models"
 )
 
 func TestCreateTeam_Success(t *testing.T) {
@@ -162,3 +164,595 @@ func TestGetTeam_NotFound(t *testing.T) {
 
 	t.Log("Non-existent team correctly returns 404")
 }
+
+func TestTeamSettings_MinimumQuorum(t *testing.T) {
+	router := GetTestRouter()
+	if router == nil {
+		t.Fatal("Test router is not initialized")
+	}
+
+	team := models.Team{
+		TeamName: "quorum-team",
+		Members: []models.TeamMember{
+			{UserID: "quorum-author", Username: "Quorum Author", IsActive: true},
+			{UserID: "quorum-reviewer", Username: "Quorum Reviewer", IsActive: true},
+		},
+	}
+
+	body, _ := json.Marshal(team)
+	req := httptest.NewRequest("POST", "/team/add", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("Failed to create team: %s", rr.Body.String())
+	}
+
+	settings := models.TeamSettings{TeamName: "quorum-team", ReviewerCount: 3, MinReviewers: 2}
+	body, _ = json.Marshal(settings)
+	req = httptest.NewRequest("POST", "/team/settings", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Failed to set team settings: %s", rr.Body.String())
+	}
+
+	createReq := transport.CreatePRRequest{
+		PullRequestID:   "quorum-pr",
+		PullRequestName: "Quorum PullRequest",
+		AuthorID:        "quorum-author",
+	}
+
+	body, _ = json.Marshal(createReq)
+	req = httptest.NewRequest("POST", "/pullRequest/create", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusConflict {
+		t.Fatalf("Expected status 409 when quorum is not met, got %v", status)
+	}
+
+	settings.MinReviewers = 1
+	body, _ = json.Marshal(settings)
+	req = httptest.NewRequest("POST", "/team/settings", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Failed to update team settings: %s", rr.Body.String())
+	}
+
+	req = httptest.NewRequest("GET", "/team/settings?team_name=quorum-team", nil)
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	var stored models.TeamSettings
+	if err := json.Unmarshal(rr.Body.Bytes(), &stored); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if stored.ReviewerCount != settings.ReviewerCount || stored.MinReviewers != settings.MinReviewers {
+		t.Errorf("Expected settings %+v, got %+v", settings, stored)
+	}
+
+	body, _ = json.Marshal(createReq)
+	req = httptest.NewRequest("POST", "/pullRequest/create", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("Expected PullRequest to be created with relaxed quorum: %s", rr.Body.String())
+	}
+
+	t.Log("Team quorum settings are enforced on PullRequest creation")
+}
+
+func TestTeamSettings_Invalid(t *testing.T) {
+	router := GetTestRouter()
+	if router == nil {
+		t.Fatal("Test router is not initialized")
+	}
+
+	settings := models.TeamSettings{TeamName: "backend", ReviewerCount: 1, MinReviewers: 2}
+	body, _ := json.Marshal(settings)
+	req := httptest.NewRequest("POST", "/team/settings", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for min_reviewers above reviewer_count, got %v", status)
+	}
+
+	req = httptest.NewRequest("GET", "/team/settings?team_name=nonexistent-team", nil)
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusNotFound {
+		t.Errorf("Expected status 404 for non-existent team, got %v", status)
+	}
+
+	t.Log("Invalid team settings correctly rejected")
+}
+
+func TestTeamSettings_FallbackTeams(t *testing.T) {
+	router := GetTestRouter()
+	if router == nil {
+		t.Fatal("Test router is not initialized")
+	}
+
+	teams := []models.Team{
+		{
+			TeamName: "solo-team",
+			Members:  []models.TeamMember{{UserID: "solo-author", Username: "Solo Author", IsActive: true}},
+		},
+		{
+			TeamName: "fallback-pool",
+			Members:  []models.TeamMember{{UserID: "fallback-reviewer", Username: "Fallback Reviewer", IsActive: true}},
+		},
+	}
+
+	for _, team := range teams {
+		body, _ := json.Marshal(team)
+		req := httptest.NewRequest("POST", "/team/add", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+
+		if rr.Code != http.StatusCreated {
+			t.Fatalf("Failed to create team %s: %s", team.TeamName, rr.Body.String())
+		}
+	}
+
+	settings := models.TeamSettings{
+		TeamName:      "solo-team",
+		ReviewerCount: 1,
+		MinReviewers:  1,
+		FallbackTeams: []string{"fallback-pool"},
+	}
+
+	body, _ := json.Marshal(settings)
+	req := httptest.NewRequest("POST", "/team/settings", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Failed to set team settings: %s", rr.Body.String())
+	}
+
+	createReq := transport.CreatePRRequest{
+		PullRequestID:   "solo-pr",
+		PullRequestName: "Solo PullRequest",
+		AuthorID:        "solo-author",
+	}
+
+	body, _ = json.Marshal(createReq)
+	req = httptest.NewRequest("POST", "/pullRequest/create", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("Expected PullRequest to be created from fallback team: %s", rr.Body.String())
+	}
+
+	var response transport.CreatePRResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+
+	if len(response.PullRequest.AssignedReviewers) != 1 || response.PullRequest.AssignedReviewers[0] != "fallback-reviewer" {
+		t.Errorf("Expected fallback-reviewer to be assigned, got %v", response.PullRequest.AssignedReviewers)
+	}
+
+	if response.PullRequest.FallbackReviewers["fallback-reviewer"] != "fallback-pool" {
+		t.Errorf("Expected fallback-reviewer to be marked as coming from fallback-pool, got %v", response.PullRequest.FallbackReviewers)
+	}
+
+	t.Log("Single-member team draws reviewers from its fallback team")
+}
+
+func postJSON(t *testing.T, router http.Handler, path string, payload any) *httptest.ResponseRecorder {
+	t.Helper()
+
+	body, _ := json.Marshal(payload)
+	req := httptest.NewRequest("POST", path, bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	return rr
+}
+
+func TestTeamSettings_PartialUpdate(t *testing.T) {
+	router := GetTestRouter()
+	if router == nil {
+		t.Fatal("Test router is not initialized")
+	}
+
+	for _, team := range []models.Team{
+		{TeamName: "partial-team", Members: []models.TeamMember{{UserID: "partial-u1", Username: "Partial 1", IsActive: true}, {UserID: "partial-u2", Username: "Partial 2", IsActive: true}}},
+		{TeamName: "partial-fallback", Members: []models.TeamMember{{UserID: "partial-fb", Username: "Partial Fallback", IsActive: true}}},
+	} {
+		if rr := postJSON(t, router, "/team/add", team); rr.Code != http.StatusCreated {
+			t.Fatalf("Failed to create team %s: %s", team.TeamName, rr.Body.String())
+		}
+	}
+
+	full := models.TeamSettings{TeamName: "partial-team", ReviewerCount: 2, MinReviewers: 1, RequiredApprovals: 2, FallbackTeams: []string{"partial-fallback"}}
+	if rr := postJSON(t, router, "/team/settings", full); rr.Code != http.StatusOK {
+		t.Fatalf("Failed to set team settings: %s", rr.Body.String())
+	}
+
+	// клиент, знающий только reviewer_count и min_reviewers, не сбрасывает остальные настройки
+	legacy := map[string]any{"team_name": "partial-team", "reviewer_count": 2, "min_reviewers": 2}
+	rr := postJSON(t, router, "/team/settings", legacy)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Failed to update team settings: %s", rr.Body.String())
+	}
+
+	var response transport.TeamSettingsResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	got := response.Settings
+	if got.MinReviewers != 2 || got.RequiredApprovals != 2 || len(got.FallbackTeams) != 1 || got.FallbackTeams[0] != "partial-fallback" {
+		t.Errorf("Expected required_approvals and fallback_teams to be kept, got %+v", got)
+	}
+
+	// ограничения проверяются на итоговых значениях
+	if rr := postJSON(t, router, "/team/settings", map[string]any{"team_name": "partial-team", "reviewer_count": 1}); rr.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for reviewer_count below min_reviewers, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if rr := postJSON(t, router, "/team/settings", map[string]any{"team_name": "partial-team"}); rr.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for empty update, got %d", rr.Code)
+	}
+}
+
+func TestTeamMembers_AddRemoveReassignsReviews(t *testing.T) {
+	router := GetTestRouter()
+	if router == nil {
+		t.Fatal("Test router is not initialized")
+	}
+
+	team := models.Team{
+		TeamName: "members-team",
+		Members: []models.TeamMember{
+			{UserID: "members-author", Username: "Members Author", IsActive: true},
+			{UserID: "members-r1", Username: "Members Reviewer 1", IsActive: true},
+			{UserID: "members-r2", Username: "Members Reviewer 2", IsActive: true},
+		},
+	}
+	if rr := postJSON(t, router, "/team/add", team); rr.Code != http.StatusCreated {
+		t.Fatalf("Failed to create team: %s", rr.Body.String())
+	}
+
+	createReq := transport.CreatePRRequest{
+		PullRequestID:   "members-pr",
+		PullRequestName: "Members PullRequest",
+		AuthorID:        "members-author",
+	}
+	if rr := postJSON(t, router, "/pullRequest/create", createReq); rr.Code != http.StatusCreated {
+		t.Fatalf("Failed to create PullRequest: %s", rr.Body.String())
+	}
+
+	addReq := models.Team{
+		TeamName: "members-team",
+		Members:  []models.TeamMember{{UserID: "members-r3", Username: "Members Reviewer 3", IsActive: true}},
+	}
+	rr := postJSON(t, router, "/team/members/add", addReq)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Failed to add team member: %s", rr.Body.String())
+	}
+
+	var added transport.TeamResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &added); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if len(added.Team.Members) != 4 {
+		t.Errorf("Expected 4 members after add, got %+v", added.Team.Members)
+	}
+
+	removeReq := transport.TeamMembersRemoveRequest{TeamName: "members-team", UserIDs: []string{"members-r1"}}
+	rr = postJSON(t, router, "/team/members/remove", removeReq)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Failed to remove team member: %s", rr.Body.String())
+	}
+
+	req := httptest.NewRequest("GET", "/pullRequest/get?pull_request_id=members-pr", nil)
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	var response transport.PRGetResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+
+	reviewers := map[string]bool{}
+	for _, id := range response.PullRequest.AssignedReviewers {
+		reviewers[id] = true
+	}
+	if reviewers["members-r1"] || !reviewers["members-r2"] || !reviewers["members-r3"] {
+		t.Errorf("Expected removed reviewer to be replaced by members-r3, got %v", response.PullRequest.AssignedReviewers)
+	}
+
+	rr = postJSON(t, router, "/team/members/remove", removeReq)
+	if rr.Code != http.StatusNotFound {
+		t.Errorf("Expected status 404 for removing a non-member, got %d", rr.Code)
+	}
+}
+
+func TestCreateTeam_ExistingUserReassignsReviews(t *testing.T) {
+	router := GetTestRouter()
+	if router == nil {
+		t.Fatal("Test router is not initialized")
+	}
+
+	createOwnersTeam(t, router, models.Team{
+		TeamName: "create-move-src",
+		Members: []models.TeamMember{
+			{UserID: "create-move-author", Username: "Create Move Author", IsActive: true},
+			{UserID: "create-move-r1", Username: "Create Move Reviewer 1", IsActive: true},
+		},
+	})
+	pr := createPRWithFiles(t, router, transport.CreatePRRequest{
+		PullRequestID:   "create-move-pr",
+		PullRequestName: "Create Move PullRequest",
+		AuthorID:        "create-move-author",
+	})
+	if !slices.Equal(pr.AssignedReviewers, []string{"create-move-r1"}) {
+		t.Fatalf("Expected create-move-r1 assigned, got %v", pr.AssignedReviewers)
+	}
+
+	addReq := models.Team{
+		TeamName: "create-move-src",
+		Members:  []models.TeamMember{{UserID: "create-move-r2", Username: "Create Move Reviewer 2", IsActive: true}},
+	}
+	if rr := postJSON(t, router, "/team/members/add", addReq); rr.Code != http.StatusOK {
+		t.Fatalf("Failed to add team member: %s", rr.Body.String())
+	}
+
+	// переход в новую команду через /team/add передаёт ревью, а не снимает его
+	team := models.Team{
+		TeamName: "create-move-dst",
+		Members:  []models.TeamMember{{UserID: "create-move-r1", Username: "Create Move Reviewer 1", IsActive: true}},
+	}
+	if rr := postJSON(t, router, "/team/add", team); rr.Code != http.StatusCreated {
+		t.Fatalf("Failed to create team: %s", rr.Body.String())
+	}
+
+	if got := getPullRequest(t, router, "create-move-pr"); !slices.Equal(got.AssignedReviewers, []string{"create-move-r2"}) {
+		t.Errorf("Expected review handed to create-move-r2, got %v", got.AssignedReviewers)
+	}
+}
+
+func TestTeam_RenameAndList(t *testing.T) {
+	router := GetTestRouter()
+	if router == nil {
+		t.Fatal("Test router is not initialized")
+	}
+
+	team := models.Team{
+		TeamName: "rename-old",
+		Members:  []models.TeamMember{{UserID: "rename-user", Username: "Rename User", IsActive: true}},
+	}
+	if rr := postJSON(t, router, "/team/add", team); rr.Code != http.StatusCreated {
+		t.Fatalf("Failed to create team: %s", rr.Body.String())
+	}
+
+	rr := postJSON(t, router, "/team/rename", transport.TeamRenameRequest{TeamName: "rename-old", NewTeamName: "rename-new"})
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Failed to rename team: %s", rr.Body.String())
+	}
+
+	var renamed transport.TeamResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &renamed); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if renamed.Team.TeamName != "rename-new" || len(renamed.Team.Members) != 1 {
+		t.Errorf("Expected renamed team with its member, got %+v", renamed.Team)
+	}
+
+	req := httptest.NewRequest("GET", "/team/get?team_name=rename-old", nil)
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	if rr.Code != http.StatusNotFound {
+		t.Errorf("Expected old team name to be gone, got status %d", rr.Code)
+	}
+
+	req = httptest.NewRequest("GET", "/team/list", nil)
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Failed to list teams: %s", rr.Body.String())
+	}
+
+	var list transport.TeamListResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &list); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+
+	found := false
+	for _, team := range list.Teams {
+		if team.TeamName == "rename-new" {
+			found = true
+		}
+		if team.TeamName == "rename-old" {
+			t.Error("Old team name still listed")
+		}
+	}
+	if !found {
+		t.Error("Expected renamed team in list")
+	}
+}
+
+func TestDeleteTeam_OpenReviewsPolicy(t *testing.T) {
+	router := GetTestRouter()
+	if router == nil {
+		t.Fatal("Test router is not initialized")
+	}
+
+	team := models.Team{
+		TeamName: "delete-team",
+		Members: []models.TeamMember{
+			{UserID: "delete-author", Username: "Delete Author", IsActive: true},
+			{UserID: "delete-reviewer", Username: "Delete Reviewer", IsActive: true},
+		},
+	}
+	if rr := postJSON(t, router, "/team/add", team); rr.Code != http.StatusCreated {
+		t.Fatalf("Failed to create team: %s", rr.Body.String())
+	}
+
+	createReq := transport.CreatePRRequest{
+		PullRequestID:   "delete-pr",
+		PullRequestName: "Delete PullRequest",
+		AuthorID:        "delete-author",
+	}
+	if rr := postJSON(t, router, "/pullRequest/create", createReq); rr.Code != http.StatusCreated {
+		t.Fatalf("Failed to create PullRequest: %s", rr.Body.String())
+	}
+
+	rr := postJSON(t, router, "/team/delete", transport.TeamDeleteRequest{TeamName: "delete-team", OpenReviews: models.OpenReviewsReject})
+	if rr.Code != http.StatusConflict {
+		t.Fatalf("Expected status 409 with reject policy, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	// без резервных команд PR остался бы без ревьюверов — удаление отклоняется
+	rr = postJSON(t, router, "/team/delete", transport.TeamDeleteRequest{TeamName: "delete-team"})
+	if rr.Code != http.StatusConflict {
+		t.Fatalf("Expected status 409 without replacement, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var errorResponse models.ErrorResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &errorResponse); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if errorResponse.Error.Code != models.TEAMORPHANS {
+		t.Errorf("Expected %s, got %s", models.TEAMORPHANS, errorResponse.Error.Code)
+	}
+
+	createOwnersTeam(t, router, models.Team{
+		TeamName: "delete-fallback",
+		Members: []models.TeamMember{
+			{UserID: "delete-fallback-rev", Username: "Delete Fallback Reviewer", IsActive: true},
+		},
+	})
+	settings := transport.TeamSettingsRequest{TeamName: "delete-team", FallbackTeams: &[]string{"delete-fallback"}}
+	if rr := postJSON(t, router, "/team/settings", settings); rr.Code != http.StatusOK {
+		t.Fatalf("Failed to set team settings: %s", rr.Body.String())
+	}
+
+	rr = postJSON(t, router, "/team/delete", transport.TeamDeleteRequest{TeamName: "delete-team"})
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Failed to delete team: %s", rr.Body.String())
+	}
+
+	var deleted transport.TeamDeleteResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &deleted); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if len(deleted.ReleasedMembers) != 2 {
+		t.Errorf("Expected 2 released members, got %v", deleted.ReleasedMembers)
+	}
+	want := []models.ReviewHandoff{{PullRequestID: "delete-pr", ReviewerID: "delete-reviewer", Action: models.ReviewReassigned, ReplacedBy: "delete-fallback-rev"}}
+	if !slices.Equal(deleted.AffectedPullRequests, want) {
+		t.Errorf("Expected %v, got %v", want, deleted.AffectedPullRequests)
+	}
+
+	if got := getPullRequest(t, router, "delete-pr"); !slices.Equal(got.AssignedReviewers, []string{"delete-fallback-rev"}) {
+		t.Errorf("Expected review handed to fallback team, got %v", got.AssignedReviewers)
+	}
+}
+
+func TestDeleteTeam_DropAndPartialReassign(t *testing.T) {
+	router := GetTestRouter()
+	if router == nil {
+		t.Fatal("Test router is not initialized")
+	}
+
+	createOwnersTeam(t, router, models.Team{
+		TeamName: "delete-part-fb",
+		Members:  []models.TeamMember{{UserID: "delete-part-fb-rev", Username: "Delete Part Fallback", IsActive: true}},
+	})
+	createOwnersTeam(t, router, models.Team{
+		TeamName: "delete-part",
+		Members: []models.TeamMember{
+			{UserID: "delete-part-author", Username: "Delete Part Author", IsActive: true},
+			{UserID: "delete-part-rev", Username: "Delete Part Reviewer", IsActive: true},
+		},
+	})
+	settings := models.TeamSettings{TeamName: "delete-part", ReviewerCount: 2, MinReviewers: 1, FallbackTeams: []string{"delete-part-fb"}}
+	if rr := postJSON(t, router, "/team/settings", settings); rr.Code != http.StatusOK {
+		t.Fatalf("Failed to set team settings: %s", rr.Body.String())
+	}
+
+	pr := createPRWithFiles(t, router, transport.CreatePRRequest{
+		PullRequestID:   "delete-part-pr",
+		PullRequestName: "Delete Part PullRequest",
+		AuthorID:        "delete-part-author",
+	})
+	if !slices.Equal(pr.AssignedReviewers, []string{"delete-part-fb-rev", "delete-part-rev"}) &&
+		!slices.Equal(pr.AssignedReviewers, []string{"delete-part-rev", "delete-part-fb-rev"}) {
+		t.Fatalf("Expected reviewers from both teams, got %v", pr.AssignedReviewers)
+	}
+
+	// замены нет, но у PR остаётся ревьювер резервной команды — удаление проходит
+	rr := postJSON(t, router, "/team/delete", transport.TeamDeleteRequest{TeamName: "delete-part"})
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Failed to delete team: %s", rr.Body.String())
+	}
+	var deleted transport.TeamDeleteResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &deleted); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	want := []models.ReviewHandoff{{PullRequestID: "delete-part-pr", ReviewerID: "delete-part-rev", Action: models.ReviewDropped}}
+	if !slices.Equal(deleted.AffectedPullRequests, want) {
+		t.Errorf("Expected %v, got %v", want, deleted.AffectedPullRequests)
+	}
+	if got := getPullRequest(t, router, "delete-part-pr"); !slices.Equal(got.AssignedReviewers, []string{"delete-part-fb-rev"}) {
+		t.Errorf("Expected fallback reviewer to remain, got %v", got.AssignedReviewers)
+	}
+
+	// с политикой drop ревью снимаются, даже если PR остаётся без ревьюверов
+	createOwnersTeam(t, router, models.Team{
+		TeamName: "delete-drop",
+		Members: []models.TeamMember{
+			{UserID: "delete-drop-author", Username: "Delete Drop Author", IsActive: true},
+			{UserID: "delete-drop-rev", Username: "Delete Drop Reviewer", IsActive: true},
+		},
+	})
+	createPRWithFiles(t, router, transport.CreatePRRequest{
+		PullRequestID:   "delete-drop-pr",
+		PullRequestName: "Delete Drop PullRequest",
+		AuthorID:        "delete-drop-author",
+	})
+
+	rr = postJSON(t, router, "/team/delete", transport.TeamDeleteRequest{TeamName: "delete-drop", OpenReviews: models.OpenReviewsDrop})
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Failed to delete team with drop policy: %s", rr.Body.String())
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), &deleted); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	want = []models.ReviewHandoff{{PullRequestID: "delete-drop-pr", ReviewerID: "delete-drop-rev", Action: models.ReviewDropped}}
+	if !slices.Equal(deleted.AffectedPullRequests, want) {
+		t.Errorf("Expected %v, got %v", want, deleted.AffectedPullRequests)
+	}
+	if got := getPullRequest(t, router, "delete-drop-pr"); len(got.AssignedReviewers) != 0 {
+		t.Errorf("Expected no reviewers after drop, got %v", got.AssignedReviewers)
+	}
+}
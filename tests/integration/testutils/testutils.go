@@ -45,11 +45,13 @@ func RunTestMigrations(db *sql.DB) error {
 		`CREATE TABLE IF NOT EXISTS users (
             id VARCHAR(255) PRIMARY KEY,
             username VARCHAR(255) NOT NULL,
-            team_name VARCHAR(255) NOT NULL,
+            team_name VARCHAR(255) NULL,
             is_active BOOLEAN DEFAULT true,
+            review_capacity INTEGER NULL CHECK (review_capacity >= 0),
+            tags TEXT[] NOT NULL DEFAULT '{}',
             created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
             updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
-            FOREIGN KEY (team_name) REFERENCES teams(team_name) ON DELETE CASCADE
+            FOREIGN KEY (team_name) REFERENCES teams(team_name) ON UPDATE CASCADE ON DELETE SET NULL
         )`,
 
 		`CREATE TABLE IF NOT EXISTS pull_requests (
@@ -59,6 +61,17 @@ func RunTestMigrations(db *sql.DB) error {
             status VARCHAR(50) DEFAULT 'OPEN',
             created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
             merged_at TIMESTAMP NULL,
+            force_merged BOOLEAN NOT NULL DEFAULT false,
+            merged_externally BOOLEAN NOT NULL DEFAULT false,
+            closed_at TIMESTAMP NULL,
+            changed_files TEXT[] NOT NULL DEFAULT '{}',
+            labels TEXT[] NOT NULL DEFAULT '{}',
+            repository VARCHAR(255) NOT NULL DEFAULT '',
+            source_branch VARCHAR(255) NOT NULL DEFAULT '',
+            target_branch VARCHAR(255) NOT NULL DEFAULT '',
+            description TEXT NOT NULL DEFAULT '',
+            url TEXT NOT NULL DEFAULT '',
+            CHECK (status IN ('DRAFT', 'OPEN', 'MERGED', 'CLOSED')),
             FOREIGN KEY (author_id) REFERENCES users(id)
         )`,
 
@@ -71,9 +84,123 @@ func RunTestMigrations(db *sql.DB) error {
             FOREIGN KEY (reviewer_id) REFERENCES users(id)
         )`,
 
+		`CREATE TABLE IF NOT EXISTS team_settings (
+            team_name VARCHAR(255) PRIMARY KEY,
+            reviewer_count INTEGER NOT NULL DEFAULT 2,
+            min_reviewers INTEGER NOT NULL DEFAULT 1,
+            required_approvals INTEGER NOT NULL DEFAULT 0 CHECK (required_approvals >= 0),
+            updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+            FOREIGN KEY (team_name) REFERENCES teams(team_name) ON UPDATE CASCADE ON DELETE CASCADE,
+            CHECK (reviewer_count > 0 AND min_reviewers >= 0 AND min_reviewers <= reviewer_count)
+        )`,
+
+		`CREATE TABLE IF NOT EXISTS team_codeowners (
+            team_name VARCHAR(255) PRIMARY KEY,
+            content TEXT NOT NULL,
+            updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+            FOREIGN KEY (team_name) REFERENCES teams(team_name) ON UPDATE CASCADE ON DELETE CASCADE
+        )`,
+
+		`CREATE TABLE IF NOT EXISTS user_availability (
+            id BIGSERIAL PRIMARY KEY,
+            user_id VARCHAR(255) NOT NULL,
+            starts_at TIMESTAMPTZ NOT NULL,
+            ends_at TIMESTAMPTZ NOT NULL,
+            reason TEXT NOT NULL DEFAULT '',
+            reassign_reviews BOOLEAN NOT NULL DEFAULT false,
+            reassigned_at TIMESTAMPTZ NULL,
+            created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+            FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+            CHECK (ends_at > starts_at)
+        )`,
+
+		`CREATE TABLE IF NOT EXISTS team_fallbacks (
+            team_name VARCHAR(255) NOT NULL,
+            fallback_team VARCHAR(255) NOT NULL,
+            position INTEGER NOT NULL,
+            PRIMARY KEY (team_name, fallback_team),
+            FOREIGN KEY (team_name) REFERENCES teams(team_name) ON UPDATE CASCADE ON DELETE CASCADE,
+            FOREIGN KEY (fallback_team) REFERENCES teams(team_name) ON UPDATE CASCADE ON DELETE CASCADE,
+            CHECK (team_name <> fallback_team)
+        )`,
+
+		`CREATE TABLE IF NOT EXISTS reviews (
+            id BIGSERIAL PRIMARY KEY,
+            pull_request_id VARCHAR(255) NOT NULL,
+            reviewer_id VARCHAR(255) NOT NULL,
+            state VARCHAR(50) NOT NULL CHECK (state IN ('APPROVED', 'CHANGES_REQUESTED', 'COMMENTED')),
+            comment TEXT NOT NULL DEFAULT '',
+            created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+            FOREIGN KEY (pull_request_id) REFERENCES pull_requests(id) ON DELETE CASCADE,
+            FOREIGN KEY (reviewer_id) REFERENCES users(id)
+        )`,
+
+		`CREATE TABLE IF NOT EXISTS assignment_events (
+            id BIGSERIAL PRIMARY KEY,
+            pull_request_id VARCHAR(255) NOT NULL,
+            action VARCHAR(50) NOT NULL CHECK (action IN ('assigned', 'reassigned', 'removed')),
+            actor VARCHAR(255) NOT NULL,
+            old_reviewer_id VARCHAR(255) NULL,
+            new_reviewer_id VARCHAR(255) NULL,
+            reason VARCHAR(50) NOT NULL,
+            created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+        )`,
+
+		`CREATE INDEX IF NOT EXISTS idx_assignment_events_pr ON assignment_events(pull_request_id, id)`,
+
+		`CREATE TABLE IF NOT EXISTS outbox_events (
+            id BIGSERIAL PRIMARY KEY,
+            event_type VARCHAR(50) NOT NULL,
+            payload JSONB NOT NULL,
+            created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+            dispatched_at TIMESTAMPTZ NULL
+        )`,
+
+		`CREATE TABLE IF NOT EXISTS webhooks (
+            id BIGSERIAL PRIMARY KEY,
+            url TEXT NOT NULL,
+            secret TEXT NOT NULL,
+            events TEXT[] NOT NULL DEFAULT '{}',
+            is_active BOOLEAN NOT NULL DEFAULT true,
+            created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+        )`,
+
+		`CREATE TABLE IF NOT EXISTS webhook_deliveries (
+            id BIGSERIAL PRIMARY KEY,
+            webhook_id BIGINT NOT NULL,
+            event_id BIGINT NOT NULL,
+            status VARCHAR(50) NOT NULL DEFAULT 'PENDING' CHECK (status IN ('PENDING', 'DELIVERED', 'DEAD')),
+            attempts INTEGER NOT NULL DEFAULT 0,
+            next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+            last_error TEXT NOT NULL DEFAULT '',
+            last_status_code INTEGER NULL,
+            delivered_at TIMESTAMPTZ NULL,
+            UNIQUE (webhook_id, event_id),
+            FOREIGN KEY (webhook_id) REFERENCES webhooks(id) ON DELETE CASCADE,
+            FOREIGN KEY (event_id) REFERENCES outbox_events(id) ON DELETE CASCADE
+        )`,
+
+		`CREATE TABLE IF NOT EXISTS reviewer_syncs (
+            pull_request_id VARCHAR(255) PRIMARY KEY,
+            status VARCHAR(50) NOT NULL CHECK (status IN ('PENDING', 'SYNCED', 'FAILED')),
+            attempts INTEGER NOT NULL DEFAULT 0,
+            last_error TEXT NOT NULL DEFAULT '',
+            next_attempt_at TIMESTAMPTZ NULL,
+            synced_at TIMESTAMPTZ NULL,
+            revision BIGINT NOT NULL DEFAULT 0,
+            pushed_reviewers TEXT[] NOT NULL DEFAULT '{}',
+            FOREIGN KEY (pull_request_id) REFERENCES pull_requests(id) ON DELETE CASCADE
+        )`,
+
+		`CREATE INDEX IF NOT EXISTS idx_outbox_events_pending ON outbox_events(id) WHERE dispatched_at IS NULL`,
+		`CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_due ON webhook_deliveries(next_attempt_at) WHERE status = 'PENDING'`,
+		`CREATE INDEX IF NOT EXISTS idx_reviewer_syncs_due ON reviewer_syncs(next_attempt_at) WHERE status <> 'SYNCED'`,
 		`CREATE INDEX IF NOT EXISTS idx_users_team_active ON users(team_name, is_active)`,
 		`CREATE INDEX IF NOT EXISTS idx_pr_status ON pull_requests(status)`,
 		`CREATE INDEX IF NOT EXISTS idx_pr_author ON pull_requests(author_id)`,
+		`CREATE INDEX IF NOT EXISTS idx_users_tags ON users USING GIN (tags)`,
+		`CREATE INDEX IF NOT EXISTS idx_pr_labels ON pull_requests USING GIN (labels)`,
+		`CREATE INDEX IF NOT EXISTS idx_pr_repository ON pull_requests(repository)`,
 		`CREATE INDEX IF NOT EXISTS idx_pr_reviewers_reviewer ON pr_reviewers(reviewer_id)`,
 	}
 
@@ -179,6 +306,16 @@ func LoadTestData(db *sql.DB) error {
 
 func CleanTestData(db *sql.DB) error {
 	tables := []string{
+		"webhook_deliveries",
+		"webhooks",
+		"outbox_events",
+		"assignment_events",
+		"reviewer_syncs",
+		"team_settings",
+		"team_codeowners",
+		"team_fallbacks",
+		"user_availability",
+		"reviews",
 		"pr_reviewers",
 		"pull_requests",
 		"users",
@@ -172,3 +172,485 @@ func TestGetUserPullRequests_NotFound(t *testing.T) {
 
 	t.Log("Non-existent user PRs correctly returns 404")
 }
+
+func TestGetUserPullRequests_Pagination(t *testing.T) {
+	router := GetTestRouter()
+	if router == nil {
+		t.Fatal("Test router is not initialized")
+	}
+
+	team := models.Team{
+		TeamName: "paging-team",
+		Members: []models.TeamMember{
+			{UserID: "paging-author", Username: "Paging Author", IsActive: true},
+			{UserID: "paging-reviewer", Username: "Paging Reviewer", IsActive: true},
+		},
+	}
+
+	body, _ := json.Marshal(team)
+	req := httptest.NewRequest("POST", "/team/add", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("Failed to create team: %s", rr.Body.String())
+	}
+
+	for _, id := range []string{"paging-pr-1", "paging-pr-2", "paging-pr-3"} {
+		createReq := transport.CreatePRRequest{
+			PullRequestID:   id,
+			PullRequestName: "Paging PullRequest",
+			AuthorID:        "paging-author",
+		}
+
+		body, _ = json.Marshal(createReq)
+		req = httptest.NewRequest("POST", "/pullRequest/create", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+
+		rr = httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+
+		if rr.Code != http.StatusCreated {
+			t.Fatalf("Failed to create PullRequest %s: %s", id, rr.Body.String())
+		}
+	}
+
+	body, _ = json.Marshal(transport.MergePRRequest{PullRequestID: "paging-pr-1"})
+	req = httptest.NewRequest("POST", "/pullRequest/merge", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Failed to merge PullRequest: %s", rr.Body.String())
+	}
+
+	seen := map[string]bool{}
+	url := "/users/getReview?user_id=paging-reviewer&limit=2"
+	for page := 0; url != ""; page++ {
+		if page > 2 {
+			t.Fatal("Pagination did not terminate")
+		}
+
+		req = httptest.NewRequest("GET", url, nil)
+		rr = httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+
+		if rr.Code != http.StatusOK {
+			t.Fatalf("Handler returned wrong status code: got %v want %v: %s", rr.Code, http.StatusOK, rr.Body.String())
+		}
+
+		var response transport.UserPRsResponse
+		if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+		for _, pr := range response.PullRequests {
+			if seen[pr.PullRequestID] {
+				t.Errorf("PullRequest %s returned twice", pr.PullRequestID)
+			}
+			seen[pr.PullRequestID] = true
+		}
+
+		url = ""
+		if response.NextCursor != "" {
+			url = "/users/getReview?user_id=paging-reviewer&limit=2&cursor=" + response.NextCursor
+		}
+	}
+
+	if len(seen) != 3 {
+		t.Errorf("Expected 3 PullRequests across pages, got %v", seen)
+	}
+
+	req = httptest.NewRequest("GET", "/users/getReview?user_id=paging-reviewer&status=OPEN", nil)
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	var response transport.UserPRsResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+
+	if len(response.PullRequests) != 2 {
+		t.Errorf("Expected 2 open PullRequests, got %+v", response.PullRequests)
+	}
+	for _, pr := range response.PullRequests {
+		if pr.Status != models.StatusOpen {
+			t.Errorf("Expected only OPEN PullRequests, got %s", pr.Status)
+		}
+	}
+}
+
+func TestGetUserPullRequests_InvalidCursor(t *testing.T) {
+	router := GetTestRouter()
+	if router == nil {
+		t.Fatal("Test router is not initialized")
+	}
+
+	req := httptest.NewRequest("GET", "/users/getReview?user_id=user2&cursor=not-a-cursor", nil)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for invalid cursor, got %v", status)
+	}
+}
+
+func TestSetUserCapacity_SkipsFullReviewers(t *testing.T) {
+	router := GetTestRouter()
+	if router == nil {
+		t.Fatal("Test router is not initialized")
+	}
+
+	team := models.Team{
+		TeamName: "capacity-team",
+		Members: []models.TeamMember{
+			{UserID: "capacity-author", Username: "Capacity Author", IsActive: true},
+			{UserID: "capacity-full", Username: "Capacity Full", IsActive: true},
+			{UserID: "capacity-free", Username: "Capacity Free", IsActive: true},
+		},
+	}
+
+	body, _ := json.Marshal(team)
+	req := httptest.NewRequest("POST", "/team/add", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("Failed to create team: %s", rr.Body.String())
+	}
+
+	capacity := 0
+	capacityReq := transport.UserSetCapacityRequest{UserID: "capacity-full", Capacity: &capacity}
+
+	body, _ = json.Marshal(capacityReq)
+	req = httptest.NewRequest("POST", "/users/setCapacity", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Failed to set capacity: %s", rr.Body.String())
+	}
+
+	var capacityResp transport.UserSetCapacityResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &capacityResp); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if capacityResp.User.ReviewCapacity == nil || *capacityResp.User.ReviewCapacity != 0 {
+		t.Errorf("Expected review capacity 0, got %v", capacityResp.User.ReviewCapacity)
+	}
+
+	createReq := transport.CreatePRRequest{
+		PullRequestID:   "capacity-pr",
+		PullRequestName: "Capacity PullRequest",
+		AuthorID:        "capacity-author",
+	}
+
+	body, _ = json.Marshal(createReq)
+	req = httptest.NewRequest("POST", "/pullRequest/create", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("Failed to create PullRequest: %s", rr.Body.String())
+	}
+
+	var response transport.CreatePRResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+
+	if len(response.PullRequest.AssignedReviewers) != 1 || response.PullRequest.AssignedReviewers[0] != "capacity-free" {
+		t.Errorf("Expected only capacity-free to be assigned, got %v", response.PullRequest.AssignedReviewers)
+	}
+
+	t.Log("Reviewers at capacity are skipped")
+}
+
+func TestSetUserCapacity_Negative(t *testing.T) {
+	router := GetTestRouter()
+	if router == nil {
+		t.Fatal("Test router is not initialized")
+	}
+
+	capacity := -1
+	capacityReq := transport.UserSetCapacityRequest{UserID: "user1", Capacity: &capacity}
+
+	body, _ := json.Marshal(capacityReq)
+	req := httptest.NewRequest("POST", "/users/setCapacity", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for negative capacity, got %v", status)
+	}
+
+	t.Log("Negative capacity correctly rejected")
+}
+
+func TestMoveUserTeam_ReviewHandoff(t *testing.T) {
+	router := GetTestRouter()
+	if router == nil {
+		t.Fatal("Test router is not initialized")
+	}
+
+	for _, team := range []models.Team{
+		{
+			TeamName: "move-src",
+			Members: []models.TeamMember{
+				{UserID: "move-author", Username: "Move Author", IsActive: true},
+				{UserID: "move-r1", Username: "Move Reviewer 1", IsActive: true},
+				{UserID: "move-r2", Username: "Move Reviewer 2", IsActive: true},
+			},
+		},
+		{
+			TeamName: "move-dst",
+			Members:  []models.TeamMember{{UserID: "move-d1", Username: "Move Destination", IsActive: true}},
+		},
+	} {
+		if rr := postJSON(t, router, "/team/add", team); rr.Code != http.StatusCreated {
+			t.Fatalf("Failed to create team: %s", rr.Body.String())
+		}
+	}
+
+	createReq := transport.CreatePRRequest{
+		PullRequestID:   "move-pr",
+		PullRequestName: "Move PullRequest",
+		AuthorID:        "move-author",
+	}
+	if rr := postJSON(t, router, "/pullRequest/create", createReq); rr.Code != http.StatusCreated {
+		t.Fatalf("Failed to create PullRequest: %s", rr.Body.String())
+	}
+
+	addReq := models.Team{
+		TeamName: "move-src",
+		Members:  []models.TeamMember{{UserID: "move-r3", Username: "Move Reviewer 3", IsActive: true}},
+	}
+	if rr := postJSON(t, router, "/team/members/add", addReq); rr.Code != http.StatusOK {
+		t.Fatalf("Failed to add team member: %s", rr.Body.String())
+	}
+
+	steps := []struct {
+		userID     string
+		teamName   string
+		reviews    string
+		action     string
+		replacedBy string
+	}{
+		{"move-r1", "move-dst", models.HandoffKeep, models.ReviewKept, ""},
+		{"move-r2", "move-dst", models.HandoffReassign, models.ReviewReassigned, "move-r3"},
+		{"move-r1", "move-src", models.HandoffDrop, models.ReviewDropped, ""},
+	}
+
+	for _, step := range steps {
+		moveReq := transport.UserMoveTeamRequest{UserID: step.userID, TeamName: step.teamName, Reviews: step.reviews}
+		rr := postJSON(t, router, "/users/moveTeam", moveReq)
+		if rr.Code != http.StatusOK {
+			t.Fatalf("Failed to move %s with %s: %s", step.userID, step.reviews, rr.Body.String())
+		}
+
+		var response transport.UserMoveTeamResponse
+		if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+
+		if response.User.TeamName != step.teamName {
+			t.Errorf("Expected %s in team %s, got %s", step.userID, step.teamName, response.User.TeamName)
+		}
+		if len(response.AffectedPullRequests) != 1 {
+			t.Fatalf("%s: expected one affected PullRequest, got %+v", step.reviews, response.AffectedPullRequests)
+		}
+
+		handoff := response.AffectedPullRequests[0]
+		if handoff.PullRequestID != "move-pr" || handoff.Action != step.action || handoff.ReplacedBy != step.replacedBy {
+			t.Errorf("%s: unexpected handoff %+v", step.reviews, handoff)
+		}
+	}
+
+	req := httptest.NewRequest("GET", "/pullRequest/get?pull_request_id=move-pr", nil)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	var response transport.PRGetResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if len(response.PullRequest.AssignedReviewers) != 1 || response.PullRequest.AssignedReviewers[0] != "move-r3" {
+		t.Errorf("Expected only move-r3 to remain assigned, got %v", response.PullRequest.AssignedReviewers)
+	}
+}
+
+func TestMoveUserTeam_ReassignWithinOldTeam(t *testing.T) {
+	router := GetTestRouter()
+	if router == nil {
+		t.Fatal("Test router is not initialized")
+	}
+
+	createOwnersTeam(t, router, models.Team{
+		TeamName: "move-fb",
+		Members:  []models.TeamMember{{UserID: "move-fb-rev", Username: "Move Fallback Reviewer", IsActive: true}},
+	})
+	createOwnersTeam(t, router, models.Team{
+		TeamName: "move-own",
+		Members: []models.TeamMember{
+			{UserID: "move-own-author", Username: "Move Own Author", IsActive: true},
+			{UserID: "move-own-rev", Username: "Move Own Reviewer", IsActive: true},
+		},
+	}, "move-fb")
+
+	pr := createPRWithFiles(t, router, transport.CreatePRRequest{
+		PullRequestID:   "move-own-pr",
+		PullRequestName: "Move Own PullRequest",
+		AuthorID:        "move-own-author",
+	})
+	if len(pr.AssignedReviewers) != 1 || pr.AssignedReviewers[0] != "move-own-rev" {
+		t.Fatalf("Expected move-own-rev assigned, got %v", pr.AssignedReviewers)
+	}
+
+	// в прежней команде замены нет, резервная команда не используется
+	moveReq := transport.UserMoveTeamRequest{UserID: "move-own-rev", TeamName: "move-fb", Reviews: models.HandoffReassign}
+	rr := postJSON(t, router, "/users/moveTeam", moveReq)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Failed to move user: %s", rr.Body.String())
+	}
+
+	var response transport.UserMoveTeamResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	want := models.ReviewHandoff{PullRequestID: "move-own-pr", Action: models.ReviewDropped}
+	if len(response.AffectedPullRequests) != 1 || response.AffectedPullRequests[0] != want {
+		t.Errorf("Expected %+v, got %+v", want, response.AffectedPullRequests)
+	}
+	if got := getPullRequest(t, router, "move-own-pr"); len(got.AssignedReviewers) != 0 {
+		t.Errorf("Expected no reviewers, got %v", got.AssignedReviewers)
+	}
+}
+
+func TestUpdateUser_TeamChangeMatchesMoveTeam(t *testing.T) {
+	router := GetTestRouter()
+	if router == nil {
+		t.Fatal("Test router is not initialized")
+	}
+
+	createOwnersTeam(t, router, models.Team{
+		TeamName: "update-fb",
+		Members:  []models.TeamMember{{UserID: "update-fb-rev", Username: "Update Fallback Reviewer", IsActive: true}},
+	})
+	createOwnersTeam(t, router, models.Team{
+		TeamName: "update-own",
+		Members: []models.TeamMember{
+			{UserID: "update-own-author", Username: "Update Own Author", IsActive: true},
+			{UserID: "update-own-rev", Username: "Update Own Reviewer", IsActive: true},
+		},
+	}, "update-fb")
+
+	createPRWithFiles(t, router, transport.CreatePRRequest{
+		PullRequestID:   "update-own-pr",
+		PullRequestName: "Update Own PullRequest",
+		AuthorID:        "update-own-author",
+	})
+
+	// смена команды через /users/update следует тем же правилам, что /users/moveTeam
+	team := "update-fb"
+	rr := postJSON(t, router, "/users/update", transport.UserUpdateRequest{UserID: "update-own-rev", TeamName: &team})
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Failed to update user: %s", rr.Body.String())
+	}
+
+	var response transport.UserUpdateResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	want := models.ReviewHandoff{PullRequestID: "update-own-pr", Action: models.ReviewDropped}
+	if len(response.AffectedPullRequests) != 1 || response.AffectedPullRequests[0] != want {
+		t.Errorf("Expected %+v, got %+v", want, response.AffectedPullRequests)
+	}
+	if got := getPullRequest(t, router, "update-own-pr"); len(got.AssignedReviewers) != 0 {
+		t.Errorf("Expected no reviewers, got %v", got.AssignedReviewers)
+	}
+}
+
+func TestUserCRUD(t *testing.T) {
+	router := GetTestRouter()
+	if router == nil {
+		t.Fatal("Test router is not initialized")
+	}
+
+	team := models.Team{
+		TeamName: "crud-team",
+		Members:  []models.TeamMember{{UserID: "crud-lead", Username: "CRUD Lead", IsActive: true}},
+	}
+	if rr := postJSON(t, router, "/team/add", team); rr.Code != http.StatusCreated {
+		t.Fatalf("Failed to create team: %s", rr.Body.String())
+	}
+
+	createReq := transport.UserCreateRequest{UserID: "crud-hire", Username: "New Hire", TeamName: "crud-team"}
+	rr := postJSON(t, router, "/users/create", createReq)
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("Failed to create user: %s", rr.Body.String())
+	}
+
+	var created transport.UserResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &created); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if !created.User.IsActive || created.User.TeamName != "crud-team" {
+		t.Errorf("Expected active user in crud-team, got %+v", created.User)
+	}
+
+	if rr := postJSON(t, router, "/users/create", createReq); rr.Code != http.StatusConflict {
+		t.Errorf("Expected status 409 for duplicate user, got %d", rr.Code)
+	}
+
+	missingTeam := transport.UserCreateRequest{UserID: "crud-lost", Username: "Lost", TeamName: "crud-missing-team"}
+	if rr := postJSON(t, router, "/users/create", missingTeam); rr.Code != http.StatusNotFound {
+		t.Errorf("Expected status 404 for unknown team, got %d", rr.Code)
+	}
+
+	username, inactive := "Renamed Hire", false
+	rr = postJSON(t, router, "/users/update", transport.UserUpdateRequest{UserID: "crud-hire", Username: &username, IsActive: &inactive})
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Failed to update user: %s", rr.Body.String())
+	}
+
+	req := httptest.NewRequest("GET", "/users/get?user_id=crud-hire", nil)
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Failed to get user: %s", rr.Body.String())
+	}
+
+	var fetched transport.UserResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &fetched); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if fetched.User.Username != username || fetched.User.IsActive || fetched.User.TeamName != "crud-team" {
+		t.Errorf("Expected renamed inactive user in crud-team, got %+v", fetched.User)
+	}
+
+	req = httptest.NewRequest("GET", "/users/list?team_name=crud-team&is_active=false", nil)
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Failed to list users: %s", rr.Body.String())
+	}
+
+	var list transport.UserListResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &list); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if len(list.Users) != 1 || list.Users[0].UserID != "crud-hire" {
+		t.Errorf("Expected only crud-hire among inactive crud-team users, got %+v", list.Users)
+	}
+}
@@ -0,0 +1,271 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/RomanKovalev007/pull_request_service/include/models"
+	"github.com/RomanKovalev007/pull_request_service/include/service"
+	transport "github.com/RomanKovalev007/pull_request_service/include/transport/models"
+)
+
+type receivedWebhook struct {
+	event     models.OutboxEvent
+	body      []byte
+	signature string
+	eventType string
+}
+
+// webhookSubscriber — тестовый подписчик, отвечающий статусом status.
+type webhookSubscriber struct {
+	*httptest.Server
+	mu       sync.Mutex
+	received []receivedWebhook
+}
+
+func newWebhookSubscriber(t *testing.T, status int) *webhookSubscriber {
+	t.Helper()
+
+	s := &webhookSubscriber{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var event models.OutboxEvent
+		json.Unmarshal(body, &event)
+
+		s.mu.Lock()
+		s.received = append(s.received, receivedWebhook{
+			event:     event,
+			body:      body,
+			signature: r.Header.Get(service.SignatureHeader),
+			eventType: r.Header.Get(service.EventHeader),
+		})
+		s.mu.Unlock()
+
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *webhookSubscriber) find(eventType, prID string) (receivedWebhook, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, got := range s.received {
+		if got.event.Type == eventType && payloadPullRequest(got.event.Payload) == prID {
+			return got, true
+		}
+	}
+	return receivedWebhook{}, false
+}
+
+func payloadPullRequest(payload json.RawMessage) string {
+	var data struct {
+		PullRequestID string `json:"pull_request_id"`
+	}
+	json.Unmarshal(payload, &data)
+	return data.PullRequestID
+}
+
+func createWebhook(t *testing.T, router http.Handler, req transport.WebhookCreateRequest) models.Webhook {
+	t.Helper()
+
+	rr := postJSON(t, router, "/webhooks/create", req)
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("Failed to create webhook: %d %s", rr.Code, rr.Body.String())
+	}
+
+	var response transport.WebhookResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+
+	t.Cleanup(func() {
+		postJSON(t, router, "/webhooks/delete", transport.WebhookDeleteRequest{ID: response.Webhook.ID})
+	})
+	return response.Webhook
+}
+
+// dispatchUntil выполняет проходы рассылки, пока done не вернёт true. В outbox могут
+// лежать события других тестов, поэтому одного прохода может не хватить.
+func dispatchUntil(t *testing.T, dispatcher *service.WebhookService, done func() bool) {
+	t.Helper()
+
+	for i := 0; i < 50; i++ {
+		if err := dispatcher.Dispatch(context.Background()); err != nil {
+			t.Fatalf("Dispatch failed: %v", err)
+		}
+		if done() {
+			return
+		}
+	}
+	t.Fatal("Expected webhook was not delivered")
+}
+
+func TestWebhooks_DeliverSignedEvents(t *testing.T) {
+	router := GetTestRouter()
+	if router == nil {
+		t.Fatal("Test router is not initialized")
+	}
+
+	subscriber := newWebhookSubscriber(t, http.StatusOK)
+	hook := createWebhook(t, router, transport.WebhookCreateRequest{
+		URL:    subscriber.URL,
+		Secret: "test-secret",
+		Events: []string{models.EventPRCreated, models.EventReviewerAssigned},
+	})
+	if hook.Secret != "test-secret" || len(hook.Events) != 2 {
+		t.Errorf("Unexpected webhook: %+v", hook)
+	}
+
+	team := models.Team{
+		TeamName: "webhook-team",
+		Members: []models.TeamMember{
+			{UserID: "webhook-author", Username: "Webhook Author", IsActive: true},
+			{UserID: "webhook-reviewer", Username: "Webhook Reviewer", IsActive: true},
+		},
+	}
+	if rr := postJSON(t, router, "/team/add", team); rr.Code != http.StatusCreated {
+		t.Fatalf("Failed to create team: %s", rr.Body.String())
+	}
+
+	rr := postJSON(t, router, "/pullRequest/create", transport.CreatePRRequest{
+		PullRequestID:   "webhook-pr",
+		PullRequestName: "Webhook PullRequest",
+		AuthorID:        "webhook-author",
+	})
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("Failed to create PR: %s", rr.Body.String())
+	}
+
+	dispatcher := service.NewWebhookService(TestRepo.WebhookRepository, service.ReviewerConfig{WebhookMaxAttempts: 3})
+	dispatchUntil(t, dispatcher, func() bool {
+		_, created := subscriber.find(models.EventPRCreated, "webhook-pr")
+		_, assigned := subscriber.find(models.EventReviewerAssigned, "webhook-pr")
+		return created && assigned
+	})
+
+	got, _ := subscriber.find(models.EventReviewerAssigned, "webhook-pr")
+	if got.signature != service.Sign("test-secret", got.body) {
+		t.Errorf("Invalid signature %q", got.signature)
+	}
+	if got.eventType != models.EventReviewerAssigned {
+		t.Errorf("Expected event header %s, got %s", models.EventReviewerAssigned, got.eventType)
+	}
+
+	var assignment models.AssignmentEvent
+	if err := json.Unmarshal(got.event.Payload, &assignment); err != nil {
+		t.Fatalf("Failed to parse payload: %v", err)
+	}
+	if assignment.NewReviewerID != "webhook-reviewer" {
+		t.Errorf("Expected webhook-reviewer to be assigned, got %+v", assignment)
+	}
+
+	if _, ok := subscriber.find(models.EventPRMerged, "webhook-pr"); ok {
+		t.Error("Subscriber must not receive events it is not subscribed to")
+	}
+}
+
+func TestWebhooks_DeadLetterAndRetry(t *testing.T) {
+	router := GetTestRouter()
+	if router == nil {
+		t.Fatal("Test router is not initialized")
+	}
+
+	subscriber := newWebhookSubscriber(t, http.StatusInternalServerError)
+	hook := createWebhook(t, router, transport.WebhookCreateRequest{
+		URL:    subscriber.URL,
+		Events: []string{models.EventPRMerged},
+	})
+	if hook.Secret == "" {
+		t.Error("Expected generated secret in create response")
+	}
+
+	team := models.Team{
+		TeamName: "webhook-dead-team",
+		Members: []models.TeamMember{
+			{UserID: "webhook-dead-author", Username: "Author", IsActive: true},
+			{UserID: "webhook-dead-reviewer", Username: "Reviewer", IsActive: true},
+		},
+	}
+	if rr := postJSON(t, router, "/team/add", team); rr.Code != http.StatusCreated {
+		t.Fatalf("Failed to create team: %s", rr.Body.String())
+	}
+	rr := postJSON(t, router, "/pullRequest/create", transport.CreatePRRequest{
+		PullRequestID:   "webhook-dead-pr",
+		PullRequestName: "Webhook Dead PullRequest",
+		AuthorID:        "webhook-dead-author",
+	})
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("Failed to create PR: %s", rr.Body.String())
+	}
+	if rr := postJSON(t, router, "/pullRequest/merge", transport.MergePRRequest{PullRequestID: "webhook-dead-pr"}); rr.Code != http.StatusOK {
+		t.Fatalf("Failed to merge PR: %s", rr.Body.String())
+	}
+
+	// подписка могла получить и события других тестов, поэтому ищется доставка своего PR
+	deadLetter := func() *models.WebhookDelivery {
+		req := httptest.NewRequest("GET", fmt.Sprintf("/webhooks/deadLetters?webhook_id=%d", hook.ID), nil)
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		if rr.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d: %s", rr.Code, rr.Body.String())
+		}
+
+		var response transport.DeadDeliveriesResponse
+		if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+		for _, d := range response.Deliveries {
+			if payloadPullRequest(d.Event.Payload) == "webhook-dead-pr" {
+				return &d
+			}
+		}
+		return nil
+	}
+
+	// нулевая задержка между попытками: повтор происходит на следующем проходе
+	dispatcher := service.NewWebhookService(TestRepo.WebhookRepository, service.ReviewerConfig{WebhookMaxAttempts: 2})
+	dispatchUntil(t, dispatcher, func() bool { return deadLetter() != nil })
+
+	d := deadLetter()
+	if d.Event.Type != models.EventPRMerged || d.Attempts != 2 || d.LastStatusCode == nil || *d.LastStatusCode != http.StatusInternalServerError {
+		t.Errorf("Unexpected dead delivery: %+v", d)
+	}
+
+	if rr := postJSON(t, router, "/webhooks/retry", transport.DeliveryRetryRequest{DeliveryID: d.ID}); rr.Code != http.StatusNoContent {
+		t.Fatalf("Expected status 204 on retry, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if dead := deadLetter(); dead != nil {
+		t.Errorf("Expected retried delivery to leave dead letters, got %+v", dead)
+	}
+
+	if rr := postJSON(t, router, "/webhooks/retry", transport.DeliveryRetryRequest{DeliveryID: d.ID}); rr.Code != http.StatusNotFound {
+		t.Errorf("Expected status 404 when retrying a pending delivery, got %d", rr.Code)
+	}
+}
+
+func TestWebhooks_InvalidCreate(t *testing.T) {
+	router := GetTestRouter()
+	if router == nil {
+		t.Fatal("Test router is not initialized")
+	}
+
+	requests := []transport.WebhookCreateRequest{
+		{URL: "not a url"},
+		{URL: "ftp://example.com/hook"},
+		{URL: "https://example.com/hook", Events: []string{"pr.unknown"}},
+	}
+
+	for _, req := range requests {
+		if rr := postJSON(t, router, "/webhooks/create", req); rr.Code != http.StatusBadRequest {
+			t.Errorf("Expected status 400 for %+v, got %d", req, rr.Code)
+		}
+	}
+}